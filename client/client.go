@@ -9,10 +9,13 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/resolver"
 
+	_ "go.linka.cloud/grpc-toolkit/codec/zstd"
 	"go.linka.cloud/grpc-toolkit/interceptors/chain"
 	"go.linka.cloud/grpc-toolkit/registry/noop"
+	"go.linka.cloud/grpc-toolkit/transport"
 )
 
 type Client interface {
@@ -20,10 +23,17 @@ type Client interface {
 }
 
 func New(opts ...Option) (Client, error) {
-	c := &client{opts: &options{dialOptions: []grpc.DialOption{grpc.WithContextDialer(dial)}}}
+	c := &client{opts: &options{
+		dialOptions:           []grpc.DialOption{grpc.WithContextDialer(dial)},
+		maxRecvMsgSize:        transport.DefaultMaxRecvMsgSize,
+		maxSendMsgSize:        transport.DefaultMaxSendMsgSize,
+		initialWindowSize:     transport.DefaultInitialWindowSize,
+		initialConnWindowSize: transport.DefaultInitialConnWindowSize,
+	}}
 	for _, o := range opts {
 		o(c.opts)
 	}
+	c.opts.dialOptions = append(c.opts.transportDialOptions(), c.opts.dialOptions...)
 	if c.opts.registry == nil {
 		c.opts.registry = noop.New()
 	}
@@ -37,6 +47,10 @@ func New(opts ...Option) (Client, error) {
 	if !c.opts.secure && c.opts.tlsConfig == nil {
 		c.opts.dialOptions = append(c.opts.dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	if c.opts.credentials != nil {
+		// grpc fails the dial if the credentials require transport security and none is configured
+		c.opts.dialOptions = append(c.opts.dialOptions, grpc.WithPerRPCCredentials(c.opts.credentials))
+	}
 	if len(c.opts.unaryInterceptors) > 0 {
 		c.opts.dialOptions = append(c.opts.dialOptions, grpc.WithUnaryInterceptor(chain.UnaryClient(c.opts.unaryInterceptors...)))
 	}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc/credentials"
+
+	"go.linka.cloud/grpc-toolkit/config/file"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// DefaultOAuth2RefreshBefore is the delay before the token expiry
+// after which the OAuth2 credentials fetch a new token.
+const DefaultOAuth2RefreshBefore = time.Minute
+
+// Credentials provides the authorization metadata sent with each call.
+// All the built-in implementations require transport security,
+// use InsecureCredentials to send them over an insecure connection, e.g. a unix socket.
+type Credentials interface {
+	credentials.PerRPCCredentials
+}
+
+// InsecureCredentials wraps the credentials so that they can be sent without transport security.
+func InsecureCredentials(c Credentials) Credentials {
+	return insecureCredentials{Credentials: c}
+}
+
+type insecureCredentials struct {
+	Credentials
+}
+
+func (insecureCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// NewTokenCredentials returns bearer token credentials using a static token.
+func NewTokenCredentials(token string) Credentials {
+	return &tokenCredentials{token: token}
+}
+
+// NewFileTokenCredentials returns bearer token credentials using the token stored in the file at path.
+// The file is watched and the token reloaded on changes until the context is canceled.
+func NewFileTokenCredentials(ctx context.Context, path string) (Credentials, error) {
+	conf, err := file.NewConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := conf.Read()
+	if err != nil {
+		return nil, err
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("%s: empty token", path)
+	}
+	c := &tokenCredentials{token: token}
+	updates := make(chan []byte)
+	if err := conf.Watch(ctx, updates); err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b := <-updates:
+				// the file may be read while being truncated: keep the last token
+				token := strings.TrimSpace(string(b))
+				if token == "" {
+					logger.C(ctx).Warnf("ignoring empty token from %s", path)
+					continue
+				}
+				logger.C(ctx).Debugf("reloading token from %s", path)
+				c.set(token)
+			}
+		}
+	}()
+	return c, nil
+}
+
+type tokenCredentials struct {
+	mu    sync.RWMutex
+	token string
+}
+
+func (c *tokenCredentials) set(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
+
+func (c *tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return map[string]string{"authorization": "bearer " + c.token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// NewOAuth2Credentials returns credentials using the OAuth2 client credentials flow.
+// The token is cached and refreshed refreshBefore its expiry, which defaults to DefaultOAuth2RefreshBefore.
+// The context is used to fetch the tokens, e.g. to provide a custom http client with oauth2.HTTPClient.
+func NewOAuth2Credentials(ctx context.Context, conf *clientcredentials.Config, refreshBefore time.Duration) Credentials {
+	if refreshBefore == 0 {
+		refreshBefore = DefaultOAuth2RefreshBefore
+	}
+	// clientcredentials.Config.Token always fetches a new token, so the caching is left to the reuse token source
+	src := tokenSourceFunc(func() (*oauth2.Token, error) {
+		return conf.Token(ctx)
+	})
+	return &oauth2Credentials{src: oauth2.ReuseTokenSourceWithExpiry(nil, src, refreshBefore)}
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (fn tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return fn()
+}
+
+type oauth2Credentials struct {
+	src oauth2.TokenSource
+}
+
+func (c *oauth2Credentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	t, err := c.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": t.Type() + " " + t.AccessToken}, nil
+}
+
+func (c *oauth2Credentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// NewBasicCredentials returns basic auth credentials.
+func NewBasicCredentials(user, password string) Credentials {
+	return &basicCredentials{auth: auth.BasicAuth(user, password)}
+}
+
+type basicCredentials struct {
+	auth string
+}
+
+func (c *basicCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": c.auth}, nil
+}
+
+func (c *basicCredentials) RequireTransportSecurity() bool {
+	return true
+}
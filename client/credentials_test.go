@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+func TestTokenCredentials(t *testing.T) {
+	c := NewTokenCredentials("token")
+	md, err := c.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "bearer token", md["authorization"])
+	assert.True(t, c.RequireTransportSecurity())
+	assert.False(t, InsecureCredentials(c).RequireTransportSecurity())
+}
+
+func TestBasicCredentials(t *testing.T) {
+	c := NewBasicCredentials("user", "password")
+	md, err := c.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, auth.BasicAuth("user", "password"), md["authorization"])
+	assert.True(t, c.RequireTransportSecurity())
+}
+
+func TestFileTokenCredentials(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("token\n"), 0o600))
+	c, err := NewFileTokenCredentials(ctx, path)
+	require.NoError(t, err)
+	md, err := c.GetRequestMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "bearer token", md["authorization"])
+
+	require.NoError(t, os.WriteFile(path, []byte("other\n"), 0o600))
+	assert.Eventually(t, func() bool {
+		md, err := c.GetRequestMetadata(ctx)
+		return err == nil && md["authorization"] == "bearer other"
+	}, time.Second, 10*time.Millisecond)
+
+	// a truncated file keeps the last token
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	time.Sleep(100 * time.Millisecond)
+	md, err = c.GetRequestMetadata(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "bearer other", md["authorization"])
+
+	_, err = NewFileTokenCredentials(ctx, path)
+	assert.Error(t, err)
+}
+
+func TestCredentialsTransportSecurity(t *testing.T) {
+	_, err := New(WithAddress("localhost:0"), WithSecure(true), WithCredentials(NewTokenCredentials("token")),
+		WithDialOptions(grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))))
+	assert.NoError(t, err)
+	_, err = New(WithAddress("localhost:0"), WithCredentials(NewTokenCredentials("token")))
+	assert.Error(t, err)
+	_, err = New(WithAddress("localhost:0"), WithCredentials(InsecureCredentials(NewTokenCredentials("token"))))
+	assert.NoError(t, err)
+}
+
+func TestOAuth2Credentials(t *testing.T) {
+	var calls atomic.Int32
+	var expiresIn atomic.Int32
+	expiresIn.Store(3600)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("grant_type") != "client_credentials" {
+			http.Error(w, "invalid grant type", http.StatusBadRequest)
+			return
+		}
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn.Load(),
+		})
+	}))
+	defer srv.Close()
+	conf := &clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: srv.URL}
+
+	c := NewOAuth2Credentials(context.Background(), conf, time.Minute)
+	for i := 0; i < 3; i++ {
+		md, err := c.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", md["authorization"])
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the token expires within the refresh window, so it should be fetched on every call
+	expiresIn.Store(30)
+	c = NewOAuth2Credentials(context.Background(), conf, time.Minute)
+	for i := 2; i < 4; i++ {
+		md, err := c.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("Bearer token-%d", i), md["authorization"])
+	}
+	assert.True(t, c.RequireTransportSecurity())
+}
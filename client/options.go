@@ -7,9 +7,11 @@ import (
 	"os"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 	"go.linka.cloud/grpc-toolkit/interceptors"
 	"go.linka.cloud/grpc-toolkit/registry"
+	"go.linka.cloud/grpc-toolkit/transport"
 )
 
 type Options interface {
@@ -23,6 +25,13 @@ type Options interface {
 	Key() string
 	TLSConfig() *tls.Config
 	DialOptions() []grpc.DialOption
+	Credentials() Credentials
+	Keepalive() *keepalive.ClientParameters
+	Compression() string
+	MaxRecvMsgSize() int
+	MaxSendMsgSize() int
+	InitialWindowSize() int32
+	InitialConnWindowSize() int32
 	UnaryInterceptors() []grpc.UnaryClientInterceptor
 	StreamInterceptors() []grpc.StreamClientInterceptor
 }
@@ -89,6 +98,72 @@ func WithDialOptions(opts ...grpc.DialOption) Option {
 	}
 }
 
+// WithCredentials sets the credentials sent with each call.
+func WithCredentials(c Credentials) Option {
+	return func(o *options) {
+		o.credentials = c
+	}
+}
+
+// WithKeepalive enables client keepalive pings.
+// Zero fields default to transport.DefaultKeepaliveTime and transport.DefaultKeepaliveTimeout.
+// The server must allow pings at least as frequent as params.Time,
+// which is the case with the service package defaults.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(o *options) {
+		if params.Time == 0 {
+			params.Time = transport.DefaultKeepaliveTime
+		}
+		if params.Timeout == 0 {
+			params.Timeout = transport.DefaultKeepaliveTimeout
+		}
+		o.keepalive = &params
+	}
+}
+
+// WithCompression sets the compressor used for all the calls, e.g. gzip or zstd.
+// The compressor must be registered on both ends, which the service package does
+// for gzip and zstd.
+func WithCompression(name string) Option {
+	return func(o *options) {
+		o.compression = name
+	}
+}
+
+// WithMaxRecvMsgSize sets the maximum message size in bytes the client can receive.
+// It defaults to transport.DefaultMaxRecvMsgSize.
+func WithMaxRecvMsgSize(size int) Option {
+	return func(o *options) {
+		o.maxRecvMsgSize = size
+	}
+}
+
+// WithMaxSendMsgSize sets the maximum message size in bytes the client can send.
+// The sent messages size is not limited by default.
+func WithMaxSendMsgSize(size int) Option {
+	return func(o *options) {
+		o.maxSendMsgSize = size
+	}
+}
+
+// WithInitialWindowSize sets the stream flow control window size.
+// Values lower than 64KB are ignored. The default, zero, keeps gRPC dynamic window sizing,
+// which any other value disables.
+func WithInitialWindowSize(size int32) Option {
+	return func(o *options) {
+		o.initialWindowSize = size
+	}
+}
+
+// WithInitialConnWindowSize sets the connection flow control window size.
+// Values lower than 64KB are ignored. The default, zero, keeps gRPC dynamic window sizing,
+// which any other value disables.
+func WithInitialConnWindowSize(size int32) Option {
+	return func(o *options) {
+		o.initialConnWindowSize = size
+	}
+}
+
 func WithInterceptors(i ...interceptors.ClientInterceptors) Option {
 	return func(o *options) {
 		for _, v := range i {
@@ -122,6 +197,14 @@ type options struct {
 	tlsConfig   *tls.Config
 	secure      bool
 	dialOptions []grpc.DialOption
+	credentials Credentials
+
+	keepalive             *keepalive.ClientParameters
+	compression           string
+	maxRecvMsgSize        int
+	maxSendMsgSize        int
+	initialWindowSize     int32
+	initialConnWindowSize int32
 
 	unaryInterceptors  []grpc.UnaryClientInterceptor
 	streamInterceptors []grpc.StreamClientInterceptor
@@ -167,6 +250,34 @@ func (o *options) DialOptions() []grpc.DialOption {
 	return o.dialOptions
 }
 
+func (o *options) Credentials() Credentials {
+	return o.credentials
+}
+
+func (o *options) Keepalive() *keepalive.ClientParameters {
+	return o.keepalive
+}
+
+func (o *options) Compression() string {
+	return o.compression
+}
+
+func (o *options) MaxRecvMsgSize() int {
+	return o.maxRecvMsgSize
+}
+
+func (o *options) MaxSendMsgSize() int {
+	return o.maxSendMsgSize
+}
+
+func (o *options) InitialWindowSize() int32 {
+	return o.initialWindowSize
+}
+
+func (o *options) InitialConnWindowSize() int32 {
+	return o.initialConnWindowSize
+}
+
 func (o *options) UnaryInterceptors() []grpc.UnaryClientInterceptor {
 	return o.unaryInterceptors
 }
@@ -175,6 +286,29 @@ func (o *options) StreamInterceptors() []grpc.StreamClientInterceptor {
 	return o.streamInterceptors
 }
 
+// transportDialOptions returns the dial options built from the typed transport options.
+// They come before the ones given with WithDialOptions so that the latter take precedence.
+func (o *options) transportDialOptions() []grpc.DialOption {
+	callOpts := []grpc.CallOption{grpc.MaxCallRecvMsgSize(o.maxRecvMsgSize)}
+	if o.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.maxSendMsgSize))
+	}
+	if o.compression != "" {
+		callOpts = append(callOpts, grpc.UseCompressor(o.compression))
+	}
+	opts := []grpc.DialOption{grpc.WithDefaultCallOptions(callOpts...)}
+	if o.keepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+	if o.initialWindowSize != 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(o.initialWindowSize))
+	}
+	if o.initialConnWindowSize != 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(o.initialConnWindowSize))
+	}
+	return opts
+}
+
 func (o *options) hasTLSConfig() bool {
 	return o.caCert != "" && o.cert != "" && o.key != "" && o.tlsConfig == nil
 }
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// echo starts a server echoing the unary requests of any method.
+func echo(t *testing.T) *bufconn.Listener {
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.MaxRecvMsgSize(16<<20), grpc.UnknownServiceHandler(func(srv any, ss grpc.ServerStream) error {
+		m := &wrapperspb.BytesValue{}
+		if err := ss.RecvMsg(m); err != nil {
+			return err
+		}
+		return ss.SendMsg(m)
+	}))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis
+}
+
+func TestTransportOptions(t *testing.T) {
+	lis := echo(t)
+	dial := WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	var compressor string
+	intercept := WithUnaryInterceptors(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		compressor = ""
+		for _, v := range opts {
+			if c, ok := v.(grpc.CompressorCallOption); ok {
+				compressor = c.CompressorType
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+	big := wrapperspb.Bytes(make([]byte, 5<<20))
+
+	c, err := New(WithAddress("bufnet"), dial, intercept)
+	require.NoError(t, err)
+	// the sent messages are not limited by default, the received ones are
+	err = c.Invoke(context.Background(), "/test.Echo/Echo", big, &wrapperspb.BytesValue{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Empty(t, compressor)
+
+	c, err = New(WithAddress("bufnet"), dial, intercept, WithMaxRecvMsgSize(16<<20), WithCompression("gzip"))
+	require.NoError(t, err)
+	out := &wrapperspb.BytesValue{}
+	require.NoError(t, c.Invoke(context.Background(), "/test.Echo/Echo", big, out))
+	assert.Len(t, out.GetValue(), 5<<20)
+	assert.Equal(t, "gzip", compressor)
+
+	c, err = New(WithAddress("bufnet"), dial, WithMaxSendMsgSize(1<<20))
+	require.NoError(t, err)
+	err = c.Invoke(context.Background(), "/test.Echo/Echo", big, &wrapperspb.BytesValue{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "larger than max")
+}
@@ -0,0 +1,85 @@
+// Package zstd registers a zstd compressor for gRPC.
+//
+// Import it for its side effect on both the client and the server,
+// then use it with client.WithCompression(zstd.Name).
+package zstd
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name registered for the zstd compressor.
+const Name = "zstd"
+
+func init() {
+	encoding.RegisterCompressor(&compressor{})
+}
+
+type compressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	enc, ok := c.encoders.Get().(*zstd.Encoder)
+	if !ok {
+		var err error
+		enc, err = zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		enc.Reset(w)
+	}
+	return &writer{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	dec, ok := c.decoders.Get().(*zstd.Decoder)
+	if !ok {
+		var err error
+		dec, err = zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+	} else if err := dec.Reset(r); err != nil {
+		c.decoders.Put(dec)
+		return nil, err
+	}
+	return &reader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+func (c *compressor) Name() string {
+	return Name
+}
+
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *writer) Close() error {
+	defer w.pool.Put(w.Encoder)
+	return w.Encoder.Close()
+}
+
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *reader) Read(p []byte) (n int, err error) {
+	if r.Decoder == nil {
+		return 0, io.EOF
+	}
+	n, err = r.Decoder.Read(p)
+	if err == io.EOF {
+		r.pool.Put(r.Decoder)
+		r.Decoder = nil
+	}
+	return n, err
+}
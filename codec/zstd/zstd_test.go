@@ -0,0 +1,33 @@
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestCompressor(t *testing.T) {
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c)
+	in := bytes.Repeat([]byte("grpc-toolkit "), 1024)
+	// run twice to go through the pooled encoder and decoder
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(in)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Less(t, buf.Len(), len(in))
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		out, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, in, out)
+	}
+}
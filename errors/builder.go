@@ -0,0 +1,115 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	status2 "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Builder builds status errors with standard details, e.g.:
+//
+//	return errors.Build(codes.InvalidArgument, "invalid user %s", name).
+//		FieldViolation("email", "must be a valid email address").
+//		ErrorInfo("INVALID_EMAIL", "example.com", nil).
+//		Err()
+//
+// The field, quota and precondition violations and the help links are gathered in a single detail each.
+type Builder struct {
+	code         codes.Code
+	msg          string
+	details      []proto.Message
+	badRequest   *errdetails.BadRequest
+	quota        *errdetails.QuotaFailure
+	precondition *errdetails.PreconditionFailure
+	help         *errdetails.Help
+}
+
+// Build returns a Builder of an error with the given code and message.
+func Build(code codes.Code, msg string, args ...interface{}) *Builder {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return &Builder{code: code, msg: msg}
+}
+
+// FieldViolation adds a BadRequest field violation.
+func (b *Builder) FieldViolation(field, description string) *Builder {
+	if b.badRequest == nil {
+		b.badRequest = &errdetails.BadRequest{}
+		b.details = append(b.details, b.badRequest)
+	}
+	b.badRequest.FieldViolations = append(b.badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+	return b
+}
+
+// ErrorInfo adds an ErrorInfo with the reason of the error, its domain and metadata.
+func (b *Builder) ErrorInfo(reason, domain string, metadata map[string]string) *Builder {
+	return b.Detail(&errdetails.ErrorInfo{Reason: reason, Domain: domain, Metadata: metadata})
+}
+
+// RetryInfo adds a RetryInfo with the delay the clients should wait before retrying.
+func (b *Builder) RetryInfo(delay time.Duration) *Builder {
+	return b.Detail(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+}
+
+// QuotaViolation adds a QuotaFailure violation.
+func (b *Builder) QuotaViolation(subject, description string) *Builder {
+	if b.quota == nil {
+		b.quota = &errdetails.QuotaFailure{}
+		b.details = append(b.details, b.quota)
+	}
+	b.quota.Violations = append(b.quota.Violations, &errdetails.QuotaFailure_Violation{Subject: subject, Description: description})
+	return b
+}
+
+// PreconditionViolation adds a PreconditionFailure violation.
+func (b *Builder) PreconditionViolation(typ, subject, description string) *Builder {
+	if b.precondition == nil {
+		b.precondition = &errdetails.PreconditionFailure{}
+		b.details = append(b.details, b.precondition)
+	}
+	b.precondition.Violations = append(b.precondition.Violations, &errdetails.PreconditionFailure_Violation{Type: typ, Subject: subject, Description: description})
+	return b
+}
+
+// ResourceInfo adds a ResourceInfo describing the resource being accessed.
+func (b *Builder) ResourceInfo(typ, name, owner, description string) *Builder {
+	return b.Detail(&errdetails.ResourceInfo{ResourceType: typ, ResourceName: name, Owner: owner, Description: description})
+}
+
+// Help adds a Help link.
+func (b *Builder) Help(description, url string) *Builder {
+	if b.help == nil {
+		b.help = &errdetails.Help{}
+		b.details = append(b.details, b.help)
+	}
+	b.help.Links = append(b.help.Links, &errdetails.Help_Link{Description: description, Url: url})
+	return b
+}
+
+// LocalizedMessage adds a LocalizedMessage in the given locale, e.g. en-US.
+func (b *Builder) LocalizedMessage(locale, message string) *Builder {
+	return b.Detail(&errdetails.LocalizedMessage{Locale: locale, Message: message})
+}
+
+// Detail adds arbitrary details.
+func (b *Builder) Detail(details ...proto.Message) *Builder {
+	b.details = append(b.details, details...)
+	return b
+}
+
+// Status returns the status of the error.
+func (b *Builder) Status() *status.Status {
+	return status.FromProto(&status2.Status{Code: int32(b.code), Message: b.msg, Details: makeDetails(b.details...)})
+}
+
+// Err returns the error.
+func (b *Builder) Err() error {
+	return b.Status().Err()
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBuilder(t *testing.T) {
+	err := Build(codes.InvalidArgument, "invalid user %s", "bob").
+		FieldViolation("email", "invalid email").
+		FieldViolation("name", "required").
+		ErrorInfo("INVALID_USER", "example.com", map[string]string{"user": "bob"}).
+		RetryInfo(time.Second).
+		QuotaViolation("user:bob", "too many requests").
+		PreconditionViolation("TOS", "user:bob", "terms not accepted").
+		ResourceInfo("user", "bob", "admin", "the user").
+		Help("docs", "https://example.com").
+		Help("faq", "https://example.com/faq").
+		LocalizedMessage("fr-FR", "utilisateur invalide").
+		Err()
+
+	s := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Equal(t, "invalid user bob", s.Message())
+	assert.Len(t, s.Details(), 8)
+
+	require.Len(t, FieldViolations(err), 2)
+	assert.Equal(t, "name", FieldViolations(err)[1].GetField())
+	assert.Equal(t, "INVALID_USER", Reason(err))
+	info, ok := ErrorInfo(err)
+	require.True(t, ok)
+	assert.Equal(t, "example.com", info.GetDomain())
+	delay, ok := RetryDelay(err)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, delay)
+	assert.Len(t, QuotaViolations(err), 1)
+	assert.Equal(t, "TOS", PreconditionViolations(err)[0].GetType())
+	r, ok := ResourceInfo(err)
+	require.True(t, ok)
+	assert.Equal(t, "bob", r.GetResourceName())
+	assert.Len(t, HelpLinks(err), 2)
+	m, ok := LocalizedMessage(err, "fr-FR")
+	require.True(t, ok)
+	assert.Equal(t, "utilisateur invalide", m.GetMessage())
+	_, ok = LocalizedMessage(err, "en-US")
+	assert.False(t, ok)
+
+	_, ok = RetryDelay(InvalidArgumentf("no details"))
+	assert.False(t, ok)
+	assert.Empty(t, Reason(nil))
+	_, ok = Detail[*errdetails.DebugInfo](err)
+	assert.False(t, ok)
+}
@@ -1,7 +1,11 @@
 package errors
 
 import (
+	"time"
+
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // BadRequestDetails returns an error details for an invalid argument.
@@ -18,3 +22,108 @@ func BadRequestDetails(fd ...string) *errdetails.BadRequest {
 		FieldViolations: fieldViolations,
 	}
 }
+
+// Details returns the details of the status error.
+func Details(err error) []proto.Message {
+	if err == nil {
+		return nil
+	}
+	var out []proto.Message
+	for _, v := range status.Convert(err).Details() {
+		if m, ok := v.(proto.Message); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Detail returns the first detail of type T of the status error.
+func Detail[T proto.Message](err error) (T, bool) {
+	for _, v := range Details(err) {
+		if d, ok := v.(T); ok {
+			return d, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// FieldViolations returns the BadRequest field violations of the status error.
+func FieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var out []*errdetails.BadRequest_FieldViolation
+	for _, v := range Details(err) {
+		if d, ok := v.(*errdetails.BadRequest); ok {
+			out = append(out, d.GetFieldViolations()...)
+		}
+	}
+	return out
+}
+
+// ErrorInfo returns the ErrorInfo of the status error.
+func ErrorInfo(err error) (*errdetails.ErrorInfo, bool) {
+	return Detail[*errdetails.ErrorInfo](err)
+}
+
+// Reason returns the reason of the ErrorInfo of the status error, or an empty string.
+func Reason(err error) string {
+	d, _ := ErrorInfo(err)
+	return d.GetReason()
+}
+
+// RetryDelay returns the retry delay of the RetryInfo of the status error.
+func RetryDelay(err error) (time.Duration, bool) {
+	d, ok := Detail[*errdetails.RetryInfo](err)
+	if !ok || d.GetRetryDelay() == nil {
+		return 0, false
+	}
+	return d.GetRetryDelay().AsDuration(), true
+}
+
+// QuotaViolations returns the QuotaFailure violations of the status error.
+func QuotaViolations(err error) []*errdetails.QuotaFailure_Violation {
+	var out []*errdetails.QuotaFailure_Violation
+	for _, v := range Details(err) {
+		if d, ok := v.(*errdetails.QuotaFailure); ok {
+			out = append(out, d.GetViolations()...)
+		}
+	}
+	return out
+}
+
+// PreconditionViolations returns the PreconditionFailure violations of the status error.
+func PreconditionViolations(err error) []*errdetails.PreconditionFailure_Violation {
+	var out []*errdetails.PreconditionFailure_Violation
+	for _, v := range Details(err) {
+		if d, ok := v.(*errdetails.PreconditionFailure); ok {
+			out = append(out, d.GetViolations()...)
+		}
+	}
+	return out
+}
+
+// ResourceInfo returns the ResourceInfo of the status error.
+func ResourceInfo(err error) (*errdetails.ResourceInfo, bool) {
+	return Detail[*errdetails.ResourceInfo](err)
+}
+
+// HelpLinks returns the Help links of the status error.
+func HelpLinks(err error) []*errdetails.Help_Link {
+	var out []*errdetails.Help_Link
+	for _, v := range Details(err) {
+		if d, ok := v.(*errdetails.Help); ok {
+			out = append(out, d.GetLinks()...)
+		}
+	}
+	return out
+}
+
+// LocalizedMessage returns the LocalizedMessage of the status error for the locale,
+// or the first one if locale is empty.
+func LocalizedMessage(err error, locale string) (*errdetails.LocalizedMessage, bool) {
+	for _, v := range Details(err) {
+		if d, ok := v.(*errdetails.LocalizedMessage); ok && (locale == "" || d.GetLocale() == locale) {
+			return d, true
+		}
+	}
+	return nil, false
+}
@@ -3,9 +3,7 @@ package errors
 import (
 	"context"
 	"errors"
-	"strings"
 
-	status2 "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -13,53 +11,53 @@ import (
 )
 
 func Canceled(err error) error {
-	return status.Error(codes.Canceled, err.Error())
+	return Wrap(codes.Canceled, err)
 }
 
 func InvalidArgument(err error) error {
-	return status.Error(codes.InvalidArgument, err.Error())
+	return Wrap(codes.InvalidArgument, err)
 }
 func DeadlineExceeded(err error) error {
-	return status.Error(codes.DeadlineExceeded, err.Error())
+	return Wrap(codes.DeadlineExceeded, err)
 }
 func NotFound(err error) error {
-	return status.Error(codes.NotFound, err.Error())
+	return Wrap(codes.NotFound, err)
 }
 func AlreadyExists(err error) error {
-	return status.Error(codes.AlreadyExists, err.Error())
+	return Wrap(codes.AlreadyExists, err)
 }
 func PermissionDenied(err error) error {
-	return status.Error(codes.PermissionDenied, err.Error())
+	return Wrap(codes.PermissionDenied, err)
 }
 func ResourceExhausted(err error) error {
-	return status.Error(codes.ResourceExhausted, err.Error())
+	return Wrap(codes.ResourceExhausted, err)
 }
 func FailedPrecondition(err error) error {
-	return status.Error(codes.FailedPrecondition, err.Error())
+	return Wrap(codes.FailedPrecondition, err)
 }
 func Aborted(err error) error {
-	return status.Error(codes.Aborted, err.Error())
+	return Wrap(codes.Aborted, err)
 }
 func OutOfRange(err error) error {
-	return status.Error(codes.OutOfRange, err.Error())
+	return Wrap(codes.OutOfRange, err)
 }
 func Unimplemented(err error) error {
-	return status.Error(codes.Unimplemented, err.Error())
+	return Wrap(codes.Unimplemented, err)
 }
 func Internal(err error) error {
-	return status.Error(codes.Internal, err.Error())
+	return Wrap(codes.Internal, err)
 }
 func Unavailable(err error) error {
-	return status.Error(codes.Unavailable, err.Error())
+	return Wrap(codes.Unavailable, err)
 }
 func DataLoss(err error) error {
-	return status.Error(codes.DataLoss, err.Error())
+	return Wrap(codes.DataLoss, err)
 }
 func Unauthenticated(err error) error {
-	return status.Error(codes.Unauthenticated, err.Error())
+	return Wrap(codes.Unauthenticated, err)
 }
 func statusErr(code codes.Code, err error, details ...proto.Message) error {
-	return status.FromProto(&status2.Status{Code: int32(code), Message: err.Error(), Details: makeDetails(details...)}).Err()
+	return Wrap(code, err, details...)
 }
 func makeDetails(m ...proto.Message) []*anypb.Any {
 	var out []*anypb.Any
@@ -259,23 +257,31 @@ func IsUnauthenticated(err error) bool {
 	return status.Convert(err).Code() == codes.Unauthenticated
 }
 
+// IsContextCanceled reports whether the error is, or wraps, context.Canceled, or is a codes.Canceled status error,
+// e.g. returned by a client whose context was canceled.
 func IsContextCanceled(err error) bool {
-	err = Unwrap(err)
 	if err == nil {
 		return false
 	}
-	return strings.Contains(err.Error(), context.Canceled.Error())
+	return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
 }
 
+// IsContextDeadlineExceeded reports whether the error is, or wraps, context.DeadlineExceeded,
+// or is a codes.DeadlineExceeded status error.
 func IsContextDeadlineExceeded(err error) bool {
-	err = Unwrap(err)
 	if err == nil {
 		return false
 	}
-	return strings.Contains(err.Error(), context.DeadlineExceeded.Error())
+	return errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded
 }
 
+// Unwrap returns the cause of the status errors created with Wrap, or an error with the status message
+// for the other status errors. The other errors are returned as is.
 func Unwrap(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.cause
+	}
 	s, ok := status.FromError(err)
 	if s == nil {
 		return nil
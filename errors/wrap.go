@@ -0,0 +1,155 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	status2 "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Error is a gRPC status error wrapping its cause, so that errors.Is and errors.As
+// find the cause while status.FromError, status.Code and the IsXXX predicates find the status.
+type Error struct {
+	s     *status.Status
+	cause error
+}
+
+// Wrap returns a status error with the given code and the cause message, wrapping the cause.
+// A nil cause still returns an error, with the code name as message.
+func Wrap(code codes.Code, cause error, details ...proto.Message) error {
+	if cause == nil {
+		return status.FromProto(&status2.Status{Code: int32(code), Message: code.String(), Details: makeDetails(details...)}).Err()
+	}
+	return &Error{
+		s:     status.FromProto(&status2.Status{Code: int32(code), Message: cause.Error(), Details: makeDetails(details...)}),
+		cause: cause,
+	}
+}
+
+// WithDetails returns the status error of err with the details added, wrapping err so that its cause is kept.
+func WithDetails(err error, details ...proto.Message) error {
+	if err == nil {
+		return nil
+	}
+	p := status.Convert(err).Proto()
+	p.Details = append(p.Details, makeDetails(details...)...)
+	return &Error{s: status.FromProto(p), cause: err}
+}
+
+func (e *Error) Error() string {
+	return e.s.Err().Error()
+}
+
+// GRPCStatus returns the status of the error.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.s
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether the target is a status error with the same status, like the grpc status errors.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(interface{ GRPCStatus() *status.Status })
+	if !ok {
+		return false
+	}
+	return proto.Equal(e.s.Proto(), t.GRPCStatus().Proto())
+}
+
+// Mapper returns the status code of the errors it handles.
+type Mapper func(err error) (codes.Code, bool)
+
+var (
+	mappersMu sync.RWMutex
+	mappers   []Mapper
+)
+
+// RegisterMapper registers a Mapper used by FromError and Code. The last registered mappers are tried first.
+func RegisterMapper(m Mapper) {
+	mappersMu.Lock()
+	defer mappersMu.Unlock()
+	mappers = append(mappers, m)
+}
+
+// MapIs returns a Mapper of the errors matching target with errors.Is to the code, e.g.:
+//
+//	errors.RegisterMapper(errors.MapIs(sql.ErrNoRows, codes.NotFound))
+func MapIs(target error, code codes.Code) Mapper {
+	return func(err error) (codes.Code, bool) {
+		return code, errors.Is(err, target)
+	}
+}
+
+// defaultMappers are tried after the registered ones.
+var defaultMappers = []Mapper{
+	MapIs(context.Canceled, codes.Canceled),
+	MapIs(context.DeadlineExceeded, codes.DeadlineExceeded),
+	MapIs(os.ErrDeadlineExceeded, codes.DeadlineExceeded),
+	MapIs(io.EOF, codes.OutOfRange),
+	MapIs(io.ErrUnexpectedEOF, codes.DataLoss),
+	MapIs(os.ErrNotExist, codes.NotFound),
+	MapIs(os.ErrExist, codes.AlreadyExists),
+	MapIs(os.ErrPermission, codes.PermissionDenied),
+}
+
+// Code returns the status code of the error: the code of the status errors, wrapped or not,
+// the code of the first matching Mapper otherwise, codes.Unknown if none matches.
+func Code(err error, ms ...Mapper) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+	if c, ok := mapCode(err, ms...); ok {
+		return c
+	}
+	return codes.Unknown
+}
+
+// FromError converts the error to a status error: the status errors are returned as is,
+// the other errors are wrapped with the code of the first matching Mapper, the given ones first,
+// or codes.Unknown if none matches.
+func FromError(err error, ms ...Mapper) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	c, ok := mapCode(err, ms...)
+	if !ok {
+		c = codes.Unknown
+	}
+	return Wrap(c, err)
+}
+
+func mapCode(err error, ms ...Mapper) (codes.Code, bool) {
+	for _, m := range ms {
+		if c, ok := m(err); ok {
+			return c, true
+		}
+	}
+	mappersMu.RLock()
+	defer mappersMu.RUnlock()
+	for i := len(mappers) - 1; i >= 0; i-- {
+		if c, ok := mappers[i](err); ok {
+			return c, true
+		}
+	}
+	for _, m := range defaultMappers {
+		if c, ok := m(err); ok {
+			return c, true
+		}
+	}
+	return codes.Unknown, false
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrap(t *testing.T) {
+	err := NotFound(os.ErrNotExist)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, os.ErrNotExist.Error(), status.Convert(err).Message())
+	assert.Equal(t, os.ErrNotExist, Unwrap(err))
+
+	var perr *os.PathError
+	err = InvalidArgumentd(&os.PathError{Op: "open", Path: "a", Err: os.ErrNotExist}, BadRequestDetails("path", "not found"))
+	require.ErrorAs(t, err, &perr)
+	assert.Equal(t, "a", perr.Path)
+	assert.Len(t, FieldViolations(err), 1)
+
+	// wrapped status errors keep their code
+	wrapped := fmt.Errorf("get user: %w", err)
+	assert.True(t, IsInvalidArgument(wrapped))
+	assert.ErrorIs(t, wrapped, os.ErrNotExist)
+	assert.ErrorIs(t, wrapped, status.Convert(err).Err())
+	assert.NotErrorIs(t, wrapped, NotFound(os.ErrNotExist))
+
+	assert.Equal(t, "message", Unwrap(status.Error(codes.Internal, "message")).Error())
+	assert.Nil(t, Unwrap(nil))
+}
+
+func TestWrapNil(t *testing.T) {
+	err := Wrap(codes.NotFound, nil)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, codes.NotFound.String(), status.Convert(err).Message())
+
+	err = InvalidArgumentd(nil, BadRequestDetails("path", "required"))
+	require.Error(t, err)
+	assert.True(t, IsInvalidArgument(err))
+	assert.Len(t, FieldViolations(err), 1)
+
+	require.Error(t, Internal(nil))
+	assert.Equal(t, codes.Internal, Code(Internal(nil)))
+}
+
+func TestWithDetails(t *testing.T) {
+	err := NotFoundd(os.ErrNotExist, BadRequestDetails("path", "not found"))
+	wrapped := fmt.Errorf("get: %w", err)
+	derr := WithDetails(wrapped, &errdetails.LocalizedMessage{Locale: "fr", Message: "introuvable"})
+	assert.ErrorIs(t, derr, os.ErrNotExist)
+	assert.True(t, IsNotFound(derr))
+	assert.Equal(t, status.Convert(wrapped).Message(), status.Convert(derr).Message())
+	assert.Len(t, FieldViolations(derr), 1)
+	m, ok := LocalizedMessage(derr, "")
+	require.True(t, ok)
+	assert.Equal(t, "introuvable", m.GetMessage())
+	assert.Nil(t, WithDetails(nil))
+}
+
+func TestIsContext(t *testing.T) {
+	assert.True(t, IsContextCanceled(fmt.Errorf("call: %w", context.Canceled)))
+	assert.True(t, IsContextCanceled(status.Error(codes.Canceled, "context canceled")))
+	assert.False(t, IsContextCanceled(errors.New("operation canceled by context canceled user")))
+	assert.False(t, IsContextCanceled(nil))
+	assert.True(t, IsContextDeadlineExceeded(DeadlineExceeded(context.DeadlineExceeded)))
+	assert.False(t, IsContextDeadlineExceeded(context.Canceled))
+}
+
+func TestFromError(t *testing.T) {
+	assert.Nil(t, FromError(nil))
+	assert.Equal(t, codes.OK, Code(nil))
+	for err, want := range map[error]codes.Code{
+		context.Canceled: codes.Canceled,
+		fmt.Errorf("%w", context.DeadlineExceeded): codes.DeadlineExceeded,
+		io.EOF:              codes.OutOfRange,
+		os.ErrNotExist:      codes.NotFound,
+		os.ErrExist:         codes.AlreadyExists,
+		os.ErrPermission:    codes.PermissionDenied,
+		errors.New("boom"):  codes.Unknown,
+		Abortedf("aborted"): codes.Aborted,
+	} {
+		assert.Equal(t, want, Code(err), err.Error())
+		assert.Equal(t, want, status.Code(FromError(err)), err.Error())
+		assert.ErrorIs(t, FromError(err), err)
+	}
+
+	errCustom := errors.New("custom")
+	RegisterMapper(MapIs(errCustom, codes.FailedPrecondition))
+	assert.Equal(t, codes.FailedPrecondition, Code(fmt.Errorf("%w", errCustom)))
+	assert.Equal(t, codes.Aborted, Code(errCustom, MapIs(errCustom, codes.Aborted)))
+
+	_, ok := Detail[*errdetails.ErrorInfo](FromError(errCustom))
+	assert.False(t, ok)
+}
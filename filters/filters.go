@@ -0,0 +1,142 @@
+package filters
+
+import (
+	"iter"
+
+	pf "go.linka.cloud/protofilters/filters"
+	"go.linka.cloud/protofilters/matcher"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/pagination"
+)
+
+// Matcher reports whether a message matches a filter.
+//
+// The go.linka.cloud/protofilters FieldsFilter taken from the request are used with FromFieldsFilter,
+// and the filters mapped by the gateway from the ?filter= query parameter to a string field
+// are first parsed with Parse.
+type Matcher interface {
+	Match(m proto.Message) (bool, error)
+}
+
+// MatcherFunc is a function implementing Matcher.
+type MatcherFunc func(m proto.Message) (bool, error)
+
+func (f MatcherFunc) Match(m proto.Message) (bool, error) {
+	return f(m)
+}
+
+// FromFieldsFilter returns a matcher of the go.linka.cloud/protofilters FieldsFilter. A nil filter matches all the messages.
+func FromFieldsFilter(f *pf.FieldsFilter) Matcher {
+	if f == nil {
+		return nil
+	}
+	return MatcherFunc(func(m proto.Message) (bool, error) {
+		ok, err := matcher.MatchFilters(m, f)
+		if err != nil {
+			return false, errors.InvalidArgumentf("invalid filter: %v", err)
+		}
+		return ok, nil
+	})
+}
+
+// Parse parses the JSON representation of a FieldsFilter, e.g. from the ?filter= query parameter:
+//
+//	?filter={"filters":{"name":{"string":{"equals":"a"}}}}
+//
+// An empty string returns a nil filter.
+func Parse(s string) (*pf.FieldsFilter, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f := &pf.FieldsFilter{}
+	if err := protojson.Unmarshal([]byte(s), f); err != nil {
+		return nil, errors.InvalidArgumentf("invalid filter: %v", err)
+	}
+	return f, nil
+}
+
+// Filter returns the messages of the sequence matching the filter. A nil matcher matches all the messages.
+func Filter[T proto.Message](seq iter.Seq2[T, error], m Matcher) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for v, err := range seq {
+			if err == nil && m != nil {
+				var ok bool
+				if ok, err = m.Match(v); err == nil && !ok {
+					continue
+				}
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Page selects a page of a listing.
+type Page struct {
+	// Size is the maximum number of items of the page, all the remaining items are returned if it is not positive.
+	Size int
+	// Token is the page token returned by the previous call, empty for the first page.
+	Token string
+	// Key identifies the filter and the order of the listing, e.g. the filter expression:
+	// the page tokens are only valid for the listings with the same key.
+	Key string
+	// Tokens signs the page tokens. It is required.
+	Tokens *pagination.Tokens
+}
+
+// List returns the page of the items matching the filter, and the token of the next page, empty on the last page.
+// A nil matcher matches all the items.
+func List[T proto.Message](items []T, m Matcher, p Page) ([]T, string, error) {
+	return ListSeq(func(yield func(T, error) bool) {
+		for _, v := range items {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}, m, p)
+}
+
+// ListSeq returns the page of the messages of the sequence matching the filter, and the token of the next page,
+// empty on the last page. The sequence is consumed up to the first match after the page.
+// A nil matcher matches all the messages.
+func ListSeq[T proto.Message](seq iter.Seq2[T, error], m Matcher, p Page) ([]T, string, error) {
+	if p.Tokens == nil {
+		return nil, "", errors.Internalf("filters: missing page tokens")
+	}
+	tk, err := p.Tokens.Decode(p.Token, p.Key)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, ok := tk.Position.(*pagination.PageToken_Cursor); ok {
+		return nil, "", errors.InvalidArgumentf("invalid page token")
+	}
+	offset := int(tk.GetOffset())
+	var (
+		out  []T
+		i    int
+		more bool
+	)
+	for v, err := range Filter(seq, m) {
+		if err != nil {
+			return nil, "", err
+		}
+		if i < offset {
+			i++
+			continue
+		}
+		if p.Size > 0 && len(out) == p.Size {
+			more = true
+			break
+		}
+		out = append(out, v)
+		i++
+	}
+	if !more {
+		return out, "", nil
+	}
+	return out, p.Tokens.Offset(uint64(i), p.Key), nil
+}
@@ -0,0 +1,134 @@
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pf "go.linka.cloud/protofilters/filters"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/pagination"
+)
+
+func fields() []*descriptorpb.FieldDescriptorProto {
+	var out []*descriptorpb.FieldDescriptorProto
+	for i := 1; i <= 10; i++ {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(fmt.Sprintf("field_%d", i)),
+			Number: proto.Int32(int32(i)),
+			Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+		if i%2 == 0 {
+			f.Type = descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+			f.Options = &descriptorpb.FieldOptions{Packed: proto.Bool(i > 5)}
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func page(size int) Page {
+	tokens, err := pagination.NewTokens([]byte("secret"))
+	if err != nil {
+		panic(err)
+	}
+	return Page{Size: size, Tokens: tokens}
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse("")
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	f, err = Parse(`{"filters":{"name":{"string":{"equals":"field_1"}}}}`)
+	require.NoError(t, err)
+	out, _, err := List(fields(), FromFieldsFilter(f), page(0))
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "field_1", out[0].GetName())
+
+	for _, s := range []string{`name = "a"`, `{"filters":{"name":{"unknown":{}}}}`} {
+		_, err = Parse(s)
+		assert.True(t, errors.IsInvalidArgument(err), s)
+	}
+}
+
+func TestList(t *testing.T) {
+	m := MatcherFunc(func(m proto.Message) (bool, error) {
+		return m.(*descriptorpb.FieldDescriptorProto).GetType() == descriptorpb.FieldDescriptorProto_TYPE_STRING, nil
+	})
+	p := page(2)
+	p.Key = "type = TYPE_STRING"
+	_, _, err := List(fields(), m, Page{Size: 2})
+	assert.True(t, errors.IsInternal(err), "the page tokens should be required")
+	var names []string
+	for {
+		out, next, err := List(fields(), m, p)
+		require.NoError(t, err)
+		for _, v := range out {
+			names = append(names, v.GetName())
+		}
+		if next == "" {
+			break
+		}
+		assert.Len(t, out, 2)
+		p.Token = next
+	}
+	assert.Equal(t, []string{"field_1", "field_3", "field_5", "field_7", "field_9"}, names)
+
+	p = page(1)
+	p.Key = "type = TYPE_STRING"
+	_, next, err := List(fields(), m, p)
+	require.NoError(t, err)
+	_, _, err = List(fields(), m, Page{Size: 1, Token: next, Key: "other", Tokens: p.Tokens})
+	assert.True(t, errors.IsInvalidArgument(err))
+	_, _, err = List(fields(), m, Page{Size: 1, Token: "invalid", Key: p.Key, Tokens: p.Tokens})
+	assert.True(t, errors.IsInvalidArgument(err))
+}
+
+func TestListSeq(t *testing.T) {
+	var read int
+	seq := func(yield func(*descriptorpb.FieldDescriptorProto, error) bool) {
+		for _, v := range fields() {
+			read++
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+	out, next, err := ListSeq(seq, MatcherFunc(func(m proto.Message) (bool, error) {
+		return m.(*descriptorpb.FieldDescriptorProto).GetNumber() > 2, nil
+	}), page(3))
+	require.NoError(t, err)
+	assert.Len(t, out, 3)
+	assert.NotEmpty(t, next)
+	assert.Equal(t, 6, read, "the source should not be read past the first match after the page")
+
+	boom := fmt.Errorf("boom")
+	_, _, err = ListSeq(seq, MatcherFunc(func(m proto.Message) (bool, error) {
+		return false, boom
+	}), page(0))
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestFromFieldsFilter(t *testing.T) {
+	assert.Nil(t, FromFieldsFilter(nil))
+
+	f := &pf.FieldsFilter{Filters: map[string]*pf.Filter{
+		"name": {Match: &pf.Filter_String_{String_: &pf.StringFilter{Condition: &pf.StringFilter_Equals{Equals: "field_2"}}}},
+	}}
+	out, _, err := List(fields(), FromFieldsFilter(f), page(0))
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "field_2", out[0].GetName())
+
+	f = &pf.FieldsFilter{Filters: map[string]*pf.Filter{
+		"unknown": {Match: &pf.Filter_String_{String_: &pf.StringFilter{Condition: &pf.StringFilter_Equals{Equals: "a"}}}},
+	}}
+	_, _, err = List(fields(), FromFieldsFilter(f), page(0))
+	assert.True(t, errors.IsInvalidArgument(err))
+}
@@ -12,6 +12,8 @@ require (
 	github.com/fatih/color v1.13.0
 	github.com/fsnotify/fsnotify v1.5.4
 	github.com/fullstorydev/grpchan v1.1.1
+	github.com/getsentry/sentry-go v0.24.1
+	github.com/go-jose/go-jose/v4 v4.1.2
 	github.com/go-logr/logr v1.4.2
 	github.com/golang/protobuf v1.5.4
 	github.com/google/uuid v1.6.0
@@ -22,6 +24,7 @@ require (
 	github.com/jaredfolkins/badactor v1.2.0
 	github.com/johnbellone/grpc-middleware-sentry v0.3.0
 	github.com/justinas/alice v1.2.0
+	github.com/klauspost/compress v1.18.0
 	github.com/miekg/dns v1.1.41
 	github.com/pires/go-proxyproto v0.7.0
 	github.com/planetscale/vtprotobuf v0.6.1-0.20240917153116-6f2963f01587
@@ -56,8 +59,11 @@ require (
 	go.opentelemetry.io/otel/trace v1.36.0
 	go.uber.org/multierr v1.7.0
 	golang.org/x/net v0.40.0
+	golang.org/x/oauth2 v0.30.0
 	golang.org/x/sync v0.14.0
 	golang.org/x/sys v0.33.0
+	golang.org/x/text v0.25.0
+	golang.org/x/time v0.11.0
 	golang.zx2c4.com/wireguard v0.0.0-20250521234502-f333402bd9cb
 	google.golang.org/genproto/googleapis/api v0.0.0-20250519155744-55703ea1f237
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250519155744-55703ea1f237
@@ -74,7 +80,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/desertbit/timer v0.0.0-20180107155436-c41aec40b27f // indirect
 	github.com/fatih/structtag v1.2.0 // indirect
-	github.com/getsentry/sentry-go v0.24.1 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/gobwas/ws v1.1.0 // indirect
 	github.com/gorilla/websocket v1.5.0 // indirect
@@ -83,7 +88,6 @@ require (
 	github.com/iancoleman/strcase v0.3.0 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/jhump/protoreflect v1.11.0 // indirect
-	github.com/klauspost/compress v1.18.0 // indirect
 	github.com/lyft/protoc-gen-star v0.6.2 // indirect
 	github.com/lyft/protoc-gen-star/v2 v2.0.4-0.20230330145011-496ad1ac90a4 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
@@ -100,7 +104,6 @@ require (
 	go.opentelemetry.io/proto/otlp v1.6.0 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	golang.org/x/mod v0.21.0 // indirect
-	golang.org/x/text v0.25.0 // indirect
 	golang.org/x/tools v0.26.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	nhooyr.io/websocket v1.8.7 // indirect
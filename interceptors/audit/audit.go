@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// NewServerInterceptors returns server interceptors writing an audit record for each call to the sink.
+//
+// The records of the unary calls contain the request, and the response of the successful calls,
+// redacted from their sensitive fields. The records of the streams do not contain any payload.
+// The interceptors must be installed after the auth interceptors for the records to contain the principal.
+// The records are written asynchronously, see WithBufferSize: Close must be called on shutdown, after the server
+// stopped, to write the buffered records. The service calls it when it stops.
+// Failing to write a record does not fail the call, the error is logged.
+func NewServerInterceptors(opts ...Option) ServerInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	if o.sink == nil {
+		o.sink = NewLoggerSink()
+	}
+	a := &audit{o: o, done: make(chan struct{})}
+	if o.bufferSize > 0 {
+		a.records = make(chan record, o.bufferSize)
+		go a.run()
+	} else {
+		close(a.done)
+	}
+	return a
+}
+
+type ServerInterceptors interface {
+	interceptors.ServerInterceptors
+	// Close waits for the buffered records to be written to the sink, or for the context to be done.
+	// The records of the calls ending after Close are written synchronously.
+	Close(ctx context.Context) error
+}
+
+type audit struct {
+	o       options
+	mu      sync.RWMutex
+	closed  bool
+	records chan record
+	done    chan struct{}
+}
+
+type record struct {
+	ctx context.Context
+	r   *AuditRecord
+}
+
+func (a *audit) run() {
+	defer close(a.done)
+	for v := range a.records {
+		a.sink(v.ctx, v.r)
+	}
+}
+
+func (a *audit) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed && a.records != nil {
+		close(a.records)
+	}
+	a.closed = true
+	a.mu.Unlock()
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *audit) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if methods.MatchAny(a.o.ignoredMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		res, err := handler(ctx, req)
+		if err != nil {
+			res = nil
+		}
+		a.write(ctx, info.FullMethod, start, req, res, err)
+		return res, err
+	}
+}
+
+func (a *audit) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if methods.MatchAny(a.o.ignoredMethods, info.FullMethod) {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		err := handler(srv, ss)
+		a.write(ss.Context(), info.FullMethod, start, nil, nil, err)
+		return err
+	}
+}
+
+func (a *audit) write(ctx context.Context, method string, start time.Time, req, res any, err error) {
+	r := &AuditRecord{
+		Time:     timestamppb.New(start),
+		Method:   method,
+		Code:     status.Code(err).String(),
+		Duration: durationpb.New(time.Since(start)),
+	}
+	if err != nil {
+		r.Error = status.Convert(err).Message()
+	}
+	if p, ok := auth.PrincipalFrom(ctx); ok {
+		r.Principal = &AuditPrincipal{
+			Subject:    p.Subject,
+			Method:     p.Method,
+			Groups:     p.Groups,
+			Roles:      p.Roles,
+			Attributes: p.Attributes,
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		r.Peer = p.Addr.String()
+	}
+	r.Request = a.payload(ctx, req)
+	r.Response = a.payload(ctx, res)
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.records == nil || a.closed {
+		a.sink(ctx, r)
+		return
+	}
+	// the call context is canceled once the call returns
+	a.records <- record{ctx: context.WithoutCancel(ctx), r: r}
+}
+
+func (a *audit) sink(ctx context.Context, r *AuditRecord) {
+	if err := a.o.sink.Write(ctx, r); err != nil {
+		logger.C(ctx).WithError(err).Errorf("failed to write audit record for %s", r.Method)
+	}
+}
+
+func (a *audit) payload(ctx context.Context, v any) *anypb.Any {
+	m, ok := v.(proto.Message)
+	if !ok || m == nil {
+		return nil
+	}
+	out, err := anypb.New(a.o.redactor.Redact(m, a.o.masks[m.ProtoReflect().Descriptor().FullName()]...))
+	if err != nil {
+		logger.C(ctx).WithError(err).Error("failed to marshal audit payload")
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,309 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: interceptors/audit/audit.proto
+
+package audit
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditRecord describes a call handled by the server.
+type AuditRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// method is the fully qualified method name, e.g. /helloworld.Greeter/SayHello.
+	Method    string          `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Principal *AuditPrincipal `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	// peer is the address of the caller.
+	Peer string `protobuf:"bytes,4,opt,name=peer,proto3" json:"peer,omitempty"`
+	// request is the redacted request, only set for unary calls.
+	Request *anypb.Any `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	// response is the redacted response, only set for successful unary calls.
+	Response *anypb.Any `protobuf:"bytes,6,opt,name=response,proto3" json:"response,omitempty"`
+	// code is the status code name, e.g. OK or PermissionDenied.
+	Code string `protobuf:"bytes,7,opt,name=code,proto3" json:"code,omitempty"`
+	// error is the status message of the failed calls.
+	Error         string               `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Duration      *durationpb.Duration `protobuf:"bytes,9,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditRecord) Reset() {
+	*x = AuditRecord{}
+	mi := &file_interceptors_audit_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditRecord) ProtoMessage() {}
+
+func (x *AuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_interceptors_audit_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditRecord.ProtoReflect.Descriptor instead.
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return file_interceptors_audit_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditRecord) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetPrincipal() *AuditPrincipal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetRequest() *anypb.Any {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetResponse() *anypb.Any {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *AuditRecord) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditRecord) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// AuditPrincipal is the authenticated caller.
+type AuditPrincipal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Groups        []string               `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditPrincipal) Reset() {
+	*x = AuditPrincipal{}
+	mi := &file_interceptors_audit_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditPrincipal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditPrincipal) ProtoMessage() {}
+
+func (x *AuditPrincipal) ProtoReflect() protoreflect.Message {
+	mi := &file_interceptors_audit_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditPrincipal.ProtoReflect.Descriptor instead.
+func (*AuditPrincipal) Descriptor() ([]byte, []int) {
+	return file_interceptors_audit_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuditPrincipal) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *AuditPrincipal) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditPrincipal) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *AuditPrincipal) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *AuditPrincipal) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+var File_interceptors_audit_audit_proto protoreflect.FileDescriptor
+
+const file_interceptors_audit_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x1einterceptors/audit/audit.proto\x12\x05linka\x1a\x19google/protobuf/any.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe1\x02\n" +
+	"\vAuditRecord\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x123\n" +
+	"\tprincipal\x18\x03 \x01(\v2\x15.linka.AuditPrincipalR\tprincipal\x12\x12\n" +
+	"\x04peer\x18\x04 \x01(\tR\x04peer\x12.\n" +
+	"\arequest\x18\x05 \x01(\v2\x14.google.protobuf.AnyR\arequest\x120\n" +
+	"\bresponse\x18\x06 \x01(\v2\x14.google.protobuf.AnyR\bresponse\x12\x12\n" +
+	"\x04code\x18\a \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x125\n" +
+	"\bduration\x18\t \x01(\v2\x19.google.protobuf.DurationR\bduration\"\xf6\x01\n" +
+	"\x0eAuditPrincipal\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x16\n" +
+	"\x06groups\x18\x03 \x03(\tR\x06groups\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12E\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\v2%.linka.AuditPrincipal.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012E\n" +
+	"\fAuditService\x125\n" +
+	"\x05Write\x12\x12.linka.AuditRecord\x1a\x16.google.protobuf.Empty(\x01B0Z.go.linka.cloud/grpc-toolkit/interceptors/auditb\x06proto3"
+
+var (
+	file_interceptors_audit_audit_proto_rawDescOnce sync.Once
+	file_interceptors_audit_audit_proto_rawDescData []byte
+)
+
+func file_interceptors_audit_audit_proto_rawDescGZIP() []byte {
+	file_interceptors_audit_audit_proto_rawDescOnce.Do(func() {
+		file_interceptors_audit_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_interceptors_audit_audit_proto_rawDesc), len(file_interceptors_audit_audit_proto_rawDesc)))
+	})
+	return file_interceptors_audit_audit_proto_rawDescData
+}
+
+var file_interceptors_audit_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_interceptors_audit_audit_proto_goTypes = []any{
+	(*AuditRecord)(nil),           // 0: linka.AuditRecord
+	(*AuditPrincipal)(nil),        // 1: linka.AuditPrincipal
+	nil,                           // 2: linka.AuditPrincipal.AttributesEntry
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*anypb.Any)(nil),             // 4: google.protobuf.Any
+	(*durationpb.Duration)(nil),   // 5: google.protobuf.Duration
+	(*emptypb.Empty)(nil),         // 6: google.protobuf.Empty
+}
+var file_interceptors_audit_audit_proto_depIdxs = []int32{
+	3, // 0: linka.AuditRecord.time:type_name -> google.protobuf.Timestamp
+	1, // 1: linka.AuditRecord.principal:type_name -> linka.AuditPrincipal
+	4, // 2: linka.AuditRecord.request:type_name -> google.protobuf.Any
+	4, // 3: linka.AuditRecord.response:type_name -> google.protobuf.Any
+	5, // 4: linka.AuditRecord.duration:type_name -> google.protobuf.Duration
+	2, // 5: linka.AuditPrincipal.attributes:type_name -> linka.AuditPrincipal.AttributesEntry
+	0, // 6: linka.AuditService.Write:input_type -> linka.AuditRecord
+	6, // 7: linka.AuditService.Write:output_type -> google.protobuf.Empty
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_interceptors_audit_audit_proto_init() }
+func file_interceptors_audit_audit_proto_init() {
+	if File_interceptors_audit_audit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interceptors_audit_audit_proto_rawDesc), len(file_interceptors_audit_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_interceptors_audit_audit_proto_goTypes,
+		DependencyIndexes: file_interceptors_audit_audit_proto_depIdxs,
+		MessageInfos:      file_interceptors_audit_audit_proto_msgTypes,
+	}.Build()
+	File_interceptors_audit_audit_proto = out.File
+	file_interceptors_audit_audit_proto_goTypes = nil
+	file_interceptors_audit_audit_proto_depIdxs = nil
+}
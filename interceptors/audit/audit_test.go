@@ -0,0 +1,232 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+type records struct {
+	mu sync.Mutex
+	r  []*AuditRecord
+}
+
+func (s *records) Write(_ context.Context, r *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.r = append(s.r, r)
+	return nil
+}
+
+func (s *records) all() []*AuditRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*AuditRecord(nil), s.r...)
+}
+
+func TestUnary(t *testing.T) {
+	s := &records{}
+	i := NewServerInterceptors(
+		WithSink(s),
+		WithFieldMask(&wrapperspb.StringValue{}, &fieldmaskpb.FieldMask{Paths: []string{"value"}}),
+		WithIgnoredMethods("/ignored/*"),
+	)
+	ctx := auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user", Method: auth.MethodBearer, Roles: []string{"admin"}})
+	call := func(method string, err error) {
+		req := wrapperspb.String("secret")
+		res, gerr := i.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return wrapperspb.Int64(42), err
+		})
+		assert.Equal(t, err, gerr)
+		if err == nil {
+			assert.True(t, proto.Equal(wrapperspb.Int64(42), res.(proto.Message)))
+		}
+		assert.Equal(t, "secret", req.Value, "the request should not be modified")
+	}
+	call("/svc/Ok", nil)
+	call("/svc/Err", status.Error(codes.NotFound, "not found"))
+	call("/ignored/Method", nil)
+
+	require.Eventually(t, func() bool {
+		return len(s.all()) == 2
+	}, time.Second, 10*time.Millisecond)
+	r := s.all()
+
+	assert.Equal(t, "/svc/Ok", r[0].Method)
+	assert.Equal(t, codes.OK.String(), r[0].Code)
+	assert.Equal(t, "user", r[0].Principal.Subject)
+	assert.Equal(t, []string{"admin"}, r[0].Principal.Roles)
+	req := &wrapperspb.StringValue{}
+	require.NoError(t, r[0].Request.UnmarshalTo(req))
+	assert.Equal(t, redact.Placeholder, req.Value)
+	res := &wrapperspb.Int64Value{}
+	require.NoError(t, r[0].Response.UnmarshalTo(res))
+	assert.Equal(t, int64(42), res.Value)
+
+	assert.Equal(t, "/svc/Err", r[1].Method)
+	assert.Equal(t, codes.NotFound.String(), r[1].Code)
+	assert.Equal(t, "not found", r[1].Error)
+	assert.NotNil(t, r[1].Request)
+	assert.Nil(t, r[1].Response)
+}
+
+func TestSinkError(t *testing.T) {
+	i := NewServerInterceptors(WithSink(SinkFunc(func(ctx context.Context, r *AuditRecord) error {
+		return errors.New("noop")
+	})))
+	_, err := i.UnaryServerInterceptor()(context.Background(), &emptypb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		return &emptypb.Empty{}, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestSlowSink(t *testing.T) {
+	written, release := make(chan struct{}), make(chan struct{})
+	i := NewServerInterceptors(WithBufferSize(1), WithSink(SinkFunc(func(ctx context.Context, r *AuditRecord) error {
+		<-release
+		close(written)
+		return nil
+	})))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := i.UnaryServerInterceptor()(context.Background(), &emptypb.Empty{}, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+			return &emptypb.Empty{}, nil
+		})
+		assert.NoError(t, err)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the call should not wait for the sink")
+	}
+	close(release)
+	<-written
+}
+
+func TestClose(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	i := NewServerInterceptors(WithBufferSize(10), WithSink(SinkFunc(func(ctx context.Context, r *AuditRecord) error {
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		methods = append(methods, r.Method)
+		return nil
+	})))
+	call := func(method string) {
+		_, err := i.UnaryServerInterceptor()(context.Background(), &emptypb.Empty{}, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return &emptypb.Empty{}, nil
+		})
+		require.NoError(t, err)
+	}
+	for range 5 {
+		call("/svc/Before")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, i.Close(ctx), context.DeadlineExceeded)
+	// the buffered records are written before Close returns
+	require.NoError(t, i.Close(context.Background()))
+	mu.Lock()
+	assert.Len(t, methods, 5)
+	mu.Unlock()
+
+	// the records of the calls ending after Close are written synchronously
+	call("/svc/After")
+	mu.Lock()
+	assert.Equal(t, "/svc/After", methods[len(methods)-1])
+	mu.Unlock()
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s, err := NewFileSink(path, 200, 2)
+	require.NoError(t, err)
+	r := &AuditRecord{Method: "/svc/Method", Code: codes.OK.String(), Error: "0123456789012345678901234567890123456789"}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, s.Write(context.Background(), r))
+	}
+	require.NoError(t, s.Close())
+	assert.Error(t, s.Write(context.Background(), r))
+
+	for _, v := range []string{path, path + ".1", path + ".2"} {
+		i, err := os.Stat(v)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, i.Size(), int64(200))
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	f, err := os.Open(path + ".1")
+	require.NoError(t, err)
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	require.True(t, sc.Scan())
+	assert.Contains(t, sc.Text(), `"/svc/Method"`)
+}
+
+type auditService struct {
+	UnimplementedAuditServiceServer
+	s *records
+}
+
+func (a *auditService) Write(ss grpc.ClientStreamingServer[AuditRecord, emptypb.Empty]) error {
+	for {
+		r, err := ss.Recv()
+		if err != nil {
+			return ss.SendAndClose(&emptypb.Empty{})
+		}
+		a.s.Write(ss.Context(), r)
+	}
+}
+
+func TestStreamSink(t *testing.T) {
+	s := &records{}
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	RegisterAuditServiceServer(srv, &auditService{s: s})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	cc, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer cc.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := NewStreamSink(ctx, cc)
+	for _, v := range []string{"/svc/A", "/svc/B"} {
+		require.NoError(t, sink.Write(context.Background(), &AuditRecord{Method: v}))
+	}
+	require.NoError(t, sink.Close())
+	require.Eventually(t, func() bool {
+		return len(s.all()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "/svc/B", s.all()[1].Method)
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+// DefaultBufferSize is the default number of records waiting to be written to the sink.
+const DefaultBufferSize = 1024
+
+var defaultOptions = options{
+	bufferSize: DefaultBufferSize,
+}
+
+type Option func(*options)
+
+// WithSink sets the sink receiving the records. It defaults to NewLoggerSink.
+func WithSink(s Sink) Option {
+	return func(o *options) {
+		o.sink = s
+	}
+}
+
+// WithBufferSize sets the number of records waiting to be written to the sink, which defaults to DefaultBufferSize.
+// The records are written by a single goroutine so that a slow sink does not delay the calls,
+// until the buffer is full, in which case the calls wait for room instead of losing records.
+// A size of zero writes the records synchronously.
+func WithBufferSize(n int) Option {
+	return func(o *options) {
+		o.bufferSize = n
+	}
+}
+
+// WithFieldMask redacts the fields of the mask from the messages of the same type as m,
+// in addition to the fields marked with the (linka.sensitive) option.
+// The masks paths can be built with the protoc-gen-go-fields generated field names.
+func WithFieldMask(m proto.Message, mask *fieldmaskpb.FieldMask) Option {
+	return func(o *options) {
+		if o.masks == nil {
+			o.masks = make(map[protoreflect.FullName][]string)
+		}
+		n := m.ProtoReflect().Descriptor().FullName()
+		o.masks[n] = append(o.masks[n], mask.GetPaths()...)
+	}
+}
+
+// WithRedactor sets the redactor of the payloads.
+// It defaults to redacting the fields marked with the (linka.sensitive) option.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// WithIgnoredMethods disables the audit of the given methods. It takes a list of fully qualified method names,
+// e.g. /grpc.health.v1.Health/Check, or wildcards, e.g. /grpc.health.v1.Health/*.
+func WithIgnoredMethods(methods ...string) Option {
+	return func(o *options) {
+		o.ignoredMethods = append(o.ignoredMethods, methods...)
+	}
+}
+
+type options struct {
+	sink           Sink
+	masks          map[protoreflect.FullName][]string
+	ignoredMethods []string
+	bufferSize     int
+	redactor       *redact.Redactor
+}
@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// Sink receives the audit records.
+type Sink interface {
+	Write(ctx context.Context, r *AuditRecord) error
+}
+
+// SinkFunc is a function implementing Sink.
+type SinkFunc func(ctx context.Context, r *AuditRecord) error
+
+func (f SinkFunc) Write(ctx context.Context, r *AuditRecord) error {
+	return f(ctx, r)
+}
+
+// NewLoggerSink returns a sink logging the records with the context logger.
+func NewLoggerSink() Sink {
+	return SinkFunc(func(ctx context.Context, r *AuditRecord) error {
+		fields := []any{
+			"grpc.method", r.Method,
+			"grpc.code", r.Code,
+			"grpc.duration", r.Duration.AsDuration().String(),
+			"peer.address", r.Peer,
+		}
+		if p := r.Principal; p != nil {
+			fields = append(fields, "auth.subject", p.Subject, "auth.method", p.Method)
+		}
+		if r.Error != "" {
+			fields = append(fields, "grpc.error", r.Error)
+		}
+		if r.Request != nil {
+			b, err := protojson.Marshal(r.Request)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, "grpc.request.content", string(b))
+		}
+		if r.Response != nil {
+			b, err := protojson.Marshal(r.Response)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, "grpc.response.content", string(b))
+		}
+		logger.C(ctx).WithFields(fields...).Info("audit")
+		return nil
+	})
+}
+
+// NewFileSink returns a sink writing the records as JSON lines to the file at path.
+// When the file would exceed maxSize bytes, it is rotated to path.1, path.1 to path.2 and so on,
+// keeping at most maxBackups files. The file is never rotated if maxSize is zero.
+func NewFileSink(path string, maxSize int64, maxBackups int) (*FileSink, error) {
+	s := &FileSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+func (s *FileSink) Write(_ context.Context, r *AuditRecord) error {
+	b, err := protojson.Marshal(r)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return os.ErrClosed
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(b)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.f.Write(b)
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	i, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.f, s.size = f, i.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+	s.f = nil
+	if s.maxBackups <= 0 {
+		if err := os.Remove(s.path); err != nil {
+			return err
+		}
+		return s.open()
+	}
+	for i := s.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(s.backup(i), s.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, s.backup(1)); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// NewStreamSink returns a sink sending the records to an AuditService through a client stream.
+// The stream is opened on the first record and reopened when sending fails.
+// The context bounds the lifetime of the stream.
+func NewStreamSink(ctx context.Context, cc grpc.ClientConnInterface) *StreamSink {
+	return &StreamSink{ctx: ctx, c: NewAuditServiceClient(cc)}
+}
+
+type StreamSink struct {
+	ctx context.Context
+	c   AuditServiceClient
+
+	mu sync.Mutex
+	s  AuditService_WriteClient
+}
+
+func (s *StreamSink) Write(_ context.Context, r *AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	// retry once with a new stream if the current one is broken
+	for i := 0; i < 2; i++ {
+		if s.s == nil {
+			if s.s, err = s.c.Write(s.ctx); err != nil {
+				return err
+			}
+		}
+		if err = s.s.Send(r); err == nil {
+			return nil
+		}
+		s.s = nil
+	}
+	return err
+}
+
+// Close closes the stream and waits for the service acknowledgement.
+func (s *StreamSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s == nil {
+		return nil
+	}
+	_, err := s.s.CloseAndRecv()
+	s.s = nil
+	return err
+}
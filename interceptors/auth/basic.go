@@ -33,7 +33,8 @@ func makeBasicAuthFunc(v BasicValidator) grpc_auth.AuthFunc {
 		if s < 0 {
 			return ctx, errors.Unauthenticatedf("malformed basic auth")
 		}
-		return v(ctx, cs[:s], cs[s+1:])
+		ctx, err = v(ctx, cs[:s], cs[s+1:])
+		return withPrincipal(ctx, err, &Principal{Subject: cs[:s], Method: MethodBasic})
 	}
 }
 
@@ -41,7 +41,14 @@ func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
 	for _, v := range opts {
 		v(&o)
 	}
-	return &interceptor{o: o, authFn: ChainedAuthFuncs(o.authFns...)}
+	fn := ChainedAuthFuncs(o.authFns...)
+	return &interceptor{o: o, authFn: func(ctx context.Context) (context.Context, error) {
+		ctx, err := fn(ctx)
+		if err != nil {
+			return ctx, err
+		}
+		return annotate(ctx), nil
+	}}
 }
 
 type interceptor struct {
@@ -0,0 +1,217 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	josejwt "github.com/go-jose/go-jose/v4/jwt"
+
+	"go.linka.cloud/grpc-toolkit/config/file"
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+// Claims are the claims of a validated token.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	Audience  []string
+	Expiry    time.Time
+	NotBefore time.Time
+	IssuedAt  time.Time
+	ID        string
+	// Raw is the JSON encoded payload of the token.
+	Raw json.RawMessage
+}
+
+// Decode unmarshals the token payload into v.
+func (c *Claims) Decode(v any) error {
+	return json.Unmarshal(c.Raw, v)
+}
+
+type claimsKey struct{}
+
+// ClaimsFrom returns the claims of the token validated by the validator.
+func ClaimsFrom(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(*Claims)
+	return c, ok
+}
+
+// CustomClaimsFrom decodes the payload of the token validated by the validator into T.
+func CustomClaimsFrom[T any](ctx context.Context) (T, error) {
+	var v T
+	c, ok := ClaimsFrom(ctx)
+	if !ok {
+		return v, fmt.Errorf("no claims in context")
+	}
+	return v, c.Decode(&v)
+}
+
+// NewValidator returns a token validator checking the JWT signature with the configured keys,
+// and the exp, nbf, iss and aud claims. The exp claim is required.
+// The claims are available in the returned context with ClaimsFrom, and the principal with auth.PrincipalFrom:
+// its subject is the sub claim, its roles and groups are read from the configured claims and its permissions
+// from the scope or scp claim.
+//
+// The context is used to watch the key set config and file.
+func NewValidator(ctx context.Context, opts ...Option) (auth.TokenValidator, error) {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	if o.discovery != "" {
+		uri, err := discover(ctx, o.client, o.discovery)
+		if err != nil {
+			return nil, err
+		}
+		if o.issuer == "" {
+			o.issuer = o.discovery
+		}
+		if o.keySetURL == "" {
+			o.keySetURL = uri
+		}
+	}
+	var ks keySets
+	if len(o.keys) != 0 {
+		ks = append(ks, staticKeys(o.keys))
+	}
+	if o.keySetFile != "" {
+		conf, err := file.NewConfig(o.keySetFile)
+		if err != nil {
+			return nil, err
+		}
+		k, err := newConfigKeys(ctx, conf)
+		if err != nil {
+			return nil, err
+		}
+		ks = append(ks, k)
+	}
+	if o.keySetConfig != nil {
+		k, err := newConfigKeys(ctx, o.keySetConfig)
+		if err != nil {
+			return nil, err
+		}
+		ks = append(ks, k)
+	}
+	if o.keySetURL != "" {
+		ks = append(ks, newRemoteKeys(o.keySetURL, o))
+	}
+	if len(ks) == 0 {
+		return nil, fmt.Errorf("jwt: no keys configured")
+	}
+	v := &validator{o: o, keys: ks}
+	for _, a := range o.algorithms {
+		v.algs = append(v.algs, jose.SignatureAlgorithm(a))
+	}
+	return v.validate, nil
+}
+
+type validator struct {
+	o    options
+	algs []jose.SignatureAlgorithm
+	keys keySet
+}
+
+func (v *validator) validate(ctx context.Context, token string) (context.Context, error) {
+	t, err := josejwt.ParseSigned(token, v.algs)
+	if err != nil {
+		return ctx, errors.Unauthenticatedf("invalid token: %v", err)
+	}
+	h := t.Headers[0]
+	keys, err := v.keys.keys(ctx, h.KeyID)
+	if err != nil {
+		return ctx, errors.Unavailablef("failed to load keys: %v", err)
+	}
+	var (
+		claims   josejwt.Claims
+		raw      json.RawMessage
+		verified bool
+	)
+	for _, k := range keys {
+		if k.Use == "enc" || (k.Algorithm != "" && k.Algorithm != h.Algorithm) {
+			continue
+		}
+		if err := t.Claims(k.Key, &claims, &raw); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return ctx, errors.Unauthenticatedf("invalid token signature")
+	}
+	if claims.Expiry == nil {
+		return ctx, errors.Unauthenticatedf("invalid token: missing exp claim")
+	}
+	e := josejwt.Expected{Issuer: v.o.issuer, AnyAudience: v.o.audience, Time: time.Now()}
+	if err := claims.ValidateWithLeeway(e, v.o.clockSkew); err != nil {
+		return ctx, errors.Unauthenticatedf("invalid token: %v", err)
+	}
+	c := &Claims{
+		Issuer:   claims.Issuer,
+		Subject:  claims.Subject,
+		Audience: claims.Audience,
+		Expiry:   claims.Expiry.Time(),
+		ID:       claims.ID,
+		Raw:      raw,
+	}
+	if claims.NotBefore != nil {
+		c.NotBefore = claims.NotBefore.Time()
+	}
+	if claims.IssuedAt != nil {
+		c.IssuedAt = claims.IssuedAt.Time()
+	}
+	ctx = context.WithValue(ctx, claimsKey{}, c)
+	return auth.ContextWithPrincipal(ctx, v.principal(c)), nil
+}
+
+func (v *validator) principal(c *Claims) *auth.Principal {
+	p := &auth.Principal{
+		Subject:    c.Subject,
+		Method:     auth.MethodBearer,
+		Attributes: map[string]string{"iss": c.Issuer},
+	}
+	var m map[string]any
+	if err := c.Decode(&m); err != nil {
+		return p
+	}
+	p.Roles = claim(m, v.o.rolesClaim)
+	p.Groups = claim(m, v.o.groupsClaim)
+	if s, ok := m["scope"].(string); ok {
+		p.Permissions = strings.Fields(s)
+	} else {
+		p.Permissions = claim(m, "scp")
+	}
+	return p
+}
+
+// claim returns the string values of the claim at the dot separated path.
+func claim(m map[string]any, path string) []string {
+	if path == "" {
+		return nil
+	}
+	var v any = m
+	for _, k := range strings.Split(path, ".") {
+		o, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = o[k]
+	}
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	josejwt "github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+type testKey struct {
+	alg  jose.SignatureAlgorithm
+	priv jose.JSONWebKey
+}
+
+func newKey(t *testing.T, kid string, alg jose.SignatureAlgorithm) testKey {
+	var (
+		k   any
+		err error
+	)
+	switch alg {
+	case jose.RS256:
+		k, err = rsa.GenerateKey(rand.Reader, 2048)
+	case jose.ES256:
+		k, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jose.EdDSA:
+		_, k, err = ed25519.GenerateKey(rand.Reader)
+	}
+	require.NoError(t, err)
+	return testKey{alg: alg, priv: jose.JSONWebKey{Key: k, KeyID: kid, Algorithm: string(alg), Use: "sig"}}
+}
+
+func (k testKey) public() jose.JSONWebKey {
+	return k.priv.Public()
+}
+
+func sign(t *testing.T, key any, alg jose.SignatureAlgorithm, claims ...any) string {
+	s, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, (&jose.SignerOptions{}).WithType("JWT"))
+	require.NoError(t, err)
+	b := josejwt.Signed(s)
+	for _, v := range claims {
+		b = b.Claims(v)
+	}
+	tk, err := b.Serialize()
+	require.NoError(t, err)
+	return tk
+}
+
+func claims(iss string, aud ...string) josejwt.Claims {
+	now := time.Now()
+	return josejwt.Claims{
+		Issuer:   iss,
+		Subject:  "user",
+		Audience: aud,
+		Expiry:   josejwt.NewNumericDate(now.Add(time.Hour)),
+		IssuedAt: josejwt.NewNumericDate(now),
+	}
+}
+
+func TestSecret(t *testing.T) {
+	ctx := context.Background()
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	v, err := NewValidator(ctx, WithSecret(secret), WithIssuer("issuer"), WithAudience("api", "other"))
+	require.NoError(t, err)
+
+	tk := sign(t, secret, jose.HS256, claims("issuer", "api"), map[string]any{"email": "user@example.com"})
+	ctx2, err := v(ctx, tk)
+	require.NoError(t, err)
+	c, ok := ClaimsFrom(ctx2)
+	require.True(t, ok)
+	assert.Equal(t, "user", c.Subject)
+	assert.Equal(t, []string{"api"}, c.Audience)
+	custom, err := CustomClaimsFrom[struct {
+		Email string `json:"email"`
+	}](ctx2)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", custom.Email)
+
+	tk = sign(t, secret, jose.HS256, claims("issuer", "api"), map[string]any{
+		"scope":        "read write",
+		"groups":       []string{"dev"},
+		"realm_access": map[string]any{"roles": []string{"admin"}},
+	})
+	v2, err := NewValidator(ctx, WithSecret(secret), WithRolesClaim("realm_access.roles"))
+	require.NoError(t, err)
+	ctx2, err = v2(ctx, tk)
+	require.NoError(t, err)
+	p, ok := auth.PrincipalFrom(ctx2)
+	require.True(t, ok)
+	assert.Equal(t, "user", p.Subject)
+	assert.Equal(t, auth.MethodBearer, p.Method)
+	assert.Equal(t, []string{"admin"}, p.Roles)
+	assert.Equal(t, []string{"dev"}, p.Groups)
+	assert.Equal(t, []string{"read", "write"}, p.Permissions)
+
+	tests := []struct {
+		name   string
+		token  func() string
+		config []Option
+	}{
+		{
+			name: "wrong secret",
+			token: func() string {
+				return sign(t, []byte("fedcba9876543210fedcba9876543210"), jose.HS256, claims("issuer", "api"))
+			},
+		},
+		{
+			name:  "wrong issuer",
+			token: func() string { return sign(t, secret, jose.HS256, claims("other", "api")) },
+		},
+		{
+			name:  "wrong audience",
+			token: func() string { return sign(t, secret, jose.HS256, claims("issuer", "unknown")) },
+		},
+		{
+			name: "expired",
+			token: func() string {
+				c := claims("issuer", "api")
+				c.Expiry = josejwt.NewNumericDate(time.Now().Add(-2 * time.Minute))
+				return sign(t, secret, jose.HS256, c)
+			},
+		},
+		{
+			name: "not yet valid",
+			token: func() string {
+				c := claims("issuer", "api")
+				c.NotBefore = josejwt.NewNumericDate(time.Now().Add(2 * time.Minute))
+				return sign(t, secret, jose.HS256, c)
+			},
+		},
+		{
+			name: "missing exp",
+			token: func() string {
+				c := claims("issuer", "api")
+				c.Expiry = nil
+				return sign(t, secret, jose.HS256, c)
+			},
+		},
+		{
+			name:   "algorithm not allowed",
+			token:  func() string { return sign(t, append(secret, secret...), jose.HS512, claims("issuer", "api")) },
+			config: []Option{WithAlgorithms("HS256")},
+		},
+		{
+			name:  "malformed",
+			token: func() string { return "not.a.token" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewValidator(ctx, append([]Option{WithSecret(secret), WithIssuer("issuer"), WithAudience("api")}, tt.config...)...)
+			require.NoError(t, err)
+			_, err = v(ctx, tt.token())
+			require.Error(t, err)
+			assert.True(t, errors.IsUnauthenticated(err))
+		})
+	}
+
+	// within the clock skew
+	skewed := claims("issuer", "api")
+	skewed.Expiry = josejwt.NewNumericDate(time.Now().Add(-30 * time.Second))
+	_, err = v(ctx, sign(t, secret, jose.HS256, skewed))
+	require.NoError(t, err)
+}
+
+func TestKeys(t *testing.T) {
+	ctx := context.Background()
+	for _, alg := range []jose.SignatureAlgorithm{jose.RS256, jose.ES256, jose.EdDSA} {
+		t.Run(string(alg), func(t *testing.T) {
+			k := newKey(t, "", alg)
+			v, err := NewValidator(ctx, WithKeys(k.public().Key))
+			require.NoError(t, err)
+			_, err = v(ctx, sign(t, k.priv, alg, claims("")))
+			require.NoError(t, err)
+
+			other := newKey(t, "", alg)
+			_, err = v(ctx, sign(t, other.priv, alg, claims("")))
+			require.Error(t, err)
+		})
+	}
+}
+
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []jose.JSONWebKey
+	fetches atomic.Int32
+	fail    atomic.Bool
+}
+
+func newJWKSServer(keys ...jose.JSONWebKey) *jwksServer {
+	s := &jwksServer{keys: keys}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": s.URL, "jwks_uri": s.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		s.fetches.Add(1)
+		if s.fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: s.keys})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *jwksServer) set(keys ...jose.JSONWebKey) {
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": "https://attacker.example.com", "jwks_uri": "https://attacker.example.com/keys"})
+	}))
+	defer s.Close()
+	_, err := NewValidator(context.Background(), WithDiscovery(s.URL))
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestDiscoveryAndRotation(t *testing.T) {
+	ctx := context.Background()
+	k1, k2 := newKey(t, "k1", jose.RS256), newKey(t, "k2", jose.ES256)
+	s := newJWKSServer(k1.public())
+	defer s.Close()
+
+	v, err := NewValidator(ctx, WithDiscovery(s.URL), WithMinRefreshInterval(0))
+	require.NoError(t, err)
+
+	_, err = v(ctx, sign(t, k1.priv, k1.alg, claims(s.URL)))
+	require.NoError(t, err)
+	_, err = v(ctx, sign(t, k1.priv, k1.alg, claims(s.URL)))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), s.fetches.Load(), "keys should be cached")
+
+	_, err = v(ctx, sign(t, k1.priv, k1.alg, claims("other")))
+	require.Error(t, err, "issuer should be set from the discovery document")
+
+	s.set(k1.public(), k2.public())
+	_, err = v(ctx, sign(t, k2.priv, k2.alg, claims(s.URL)))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), s.fetches.Load(), "unknown key id should trigger a fetch")
+
+	// the previous keys are kept if the endpoint fails
+	s.fail.Store(true)
+	k3 := newKey(t, "k3", jose.EdDSA)
+	_, err = v(ctx, sign(t, k3.priv, k3.alg, claims(s.URL)))
+	require.Error(t, err)
+	assert.Equal(t, int32(3), s.fetches.Load())
+	_, err = v(ctx, sign(t, k1.priv, k1.alg, claims(s.URL)))
+	require.NoError(t, err)
+}
+
+func TestMinRefreshInterval(t *testing.T) {
+	ctx := context.Background()
+	k1, k2 := newKey(t, "k1", jose.EdDSA), newKey(t, "k2", jose.EdDSA)
+	s := newJWKSServer(k1.public())
+	defer s.Close()
+
+	v, err := NewValidator(ctx, WithKeySetURL(s.URL+"/keys"))
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = v(ctx, sign(t, k2.priv, k2.alg, claims("")))
+		require.Error(t, err)
+	}
+	assert.Equal(t, int32(1), s.fetches.Load())
+}
+
+func TestKeySetFile(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	k1, k2 := newKey(t, "k1", jose.ES256), newKey(t, "k2", jose.ES256)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	write := func(keys ...jose.JSONWebKey) {
+		b, err := json.Marshal(jose.JSONWebKeySet{Keys: keys})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, b, 0o600))
+	}
+	write(k1.public())
+
+	v, err := NewValidator(ctx, WithKeySetFile(path))
+	require.NoError(t, err)
+	_, err = v(ctx, sign(t, k1.priv, k1.alg, claims("")))
+	require.NoError(t, err)
+	_, err = v(ctx, sign(t, k2.priv, k2.alg, claims("")))
+	require.Error(t, err)
+
+	write(k2.public())
+	assert.Eventually(t, func() bool {
+		_, err := v(ctx, sign(t, k2.priv, k2.alg, claims("")))
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,223 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"go.linka.cloud/grpc-toolkit/config"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+type keySet interface {
+	// keys returns the keys matching the key id, or all the keys if the key id is empty.
+	keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error)
+}
+
+type keySets []keySet
+
+func (s keySets) keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	var out []jose.JSONWebKey
+	for _, v := range s {
+		ks, err := v.keys(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ks...)
+	}
+	return out, nil
+}
+
+// staticKeys are the keys provided with the options, they match any key id if they do not have one.
+type staticKeys []jose.JSONWebKey
+
+func (s staticKeys) keys(_ context.Context, kid string) ([]jose.JSONWebKey, error) {
+	var out []jose.JSONWebKey
+	for _, v := range s {
+		if kid == "" || v.KeyID == "" || v.KeyID == kid {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func newConfigKeys(ctx context.Context, conf config.Config) (*configKeys, error) {
+	b, err := conf.Read()
+	if err != nil {
+		return nil, err
+	}
+	set, err := parseKeySet(b)
+	if err != nil {
+		return nil, err
+	}
+	k := &configKeys{set: set}
+	updates := make(chan []byte)
+	if err := conf.Watch(ctx, updates); err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b := <-updates:
+				set, err := parseKeySet(b)
+				if err != nil {
+					logger.C(ctx).WithError(err).Error("failed to parse key set")
+					continue
+				}
+				k.mu.Lock()
+				k.set = set
+				k.mu.Unlock()
+			}
+		}
+	}()
+	return k, nil
+}
+
+type configKeys struct {
+	mu  sync.RWMutex
+	set []jose.JSONWebKey
+}
+
+func (c *configKeys) keys(_ context.Context, kid string) ([]jose.JSONWebKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return filterKeys(c.set, kid), nil
+}
+
+func newRemoteKeys(url string, o options) *remoteKeys {
+	return &remoteKeys{url: url, client: o.client, refresh: o.refreshInterval, minRefresh: o.minRefreshInterval}
+}
+
+type remoteKeys struct {
+	url        string
+	client     *http.Client
+	refresh    time.Duration
+	minRefresh time.Duration
+
+	mu      sync.RWMutex
+	set     []jose.JSONWebKey
+	fetched time.Time
+	// fetch serializes the fetches
+	fetch sync.Mutex
+}
+
+func (r *remoteKeys) keys(ctx context.Context, kid string) ([]jose.JSONWebKey, error) {
+	r.mu.RLock()
+	set, fetched := r.set, r.fetched
+	r.mu.RUnlock()
+	if time.Since(fetched) > r.refresh {
+		var err error
+		set, fetched, err = r.update(ctx, fetched)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ks := filterKeys(set, kid)
+	if len(ks) != 0 || kid == "" || time.Since(fetched) < r.minRefresh {
+		return ks, nil
+	}
+	// the keys may have been rotated
+	set, _, err := r.update(ctx, fetched)
+	if err != nil {
+		return nil, err
+	}
+	return filterKeys(set, kid), nil
+}
+
+// update fetches the key set if it was not fetched since seen. On failure, the previous keys are kept if any.
+func (r *remoteKeys) update(ctx context.Context, seen time.Time) ([]jose.JSONWebKey, time.Time, error) {
+	r.fetch.Lock()
+	defer r.fetch.Unlock()
+	r.mu.RLock()
+	set, fetched := r.set, r.fetched
+	r.mu.RUnlock()
+	if fetched.After(seen) {
+		return set, fetched, nil
+	}
+	b, err := get(ctx, r.client, r.url)
+	if err == nil {
+		set, err = parseKeySet(b)
+	}
+	if err != nil {
+		if len(r.set) == 0 {
+			return nil, fetched, fmt.Errorf("fetch key set: %w", err)
+		}
+		logger.C(ctx).WithError(err).Warnf("failed to fetch key set from %s, using cached keys", r.url)
+		set = r.set
+	}
+	r.mu.Lock()
+	r.set, r.fetched = set, time.Now()
+	fetched = r.fetched
+	r.mu.Unlock()
+	return set, fetched, nil
+}
+
+// discover returns the JWKS endpoint from the OpenID Connect discovery document of the issuer.
+func discover(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	b, err := get(ctx, client, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration")
+	if err != nil {
+		return "", fmt.Errorf("fetch discovery document: %w", err)
+	}
+	var doc struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return "", fmt.Errorf("parse discovery document: %w", err)
+	}
+	// the issuer must be identical to the one used to fetch the document
+	if doc.Issuer != issuer {
+		return "", fmt.Errorf("discovery document: issuer %q does not match %q", doc.Issuer, issuer)
+	}
+	if doc.JWKSURI == "" {
+		return "", fmt.Errorf("discovery document: missing jwks_uri")
+	}
+	return doc.JWKSURI, nil
+}
+
+func get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", url, res.Status)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+func parseKeySet(b []byte) ([]jose.JSONWebKey, error) {
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("parse key set: %w", err)
+	}
+	return set.Keys, nil
+}
+
+func filterKeys(set []jose.JSONWebKey, kid string) []jose.JSONWebKey {
+	if kid == "" {
+		return set
+	}
+	var out []jose.JSONWebKey
+	for _, v := range set {
+		if v.KeyID == kid {
+			out = append(out, v)
+		}
+	}
+	return out
+}
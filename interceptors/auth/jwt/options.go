@@ -0,0 +1,185 @@
+package jwt
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"go.linka.cloud/grpc-toolkit/config"
+)
+
+const (
+	// DefaultClockSkew is the leeway used when checking the exp, nbf and iat claims.
+	DefaultClockSkew = time.Minute
+	// DefaultRefreshInterval is the interval at which a remote key set is fetched again.
+	DefaultRefreshInterval = 15 * time.Minute
+	// DefaultMinRefreshInterval is the minimum interval between two fetches of a remote key set
+	// triggered by an unknown key id.
+	DefaultMinRefreshInterval = 10 * time.Second
+)
+
+// DefaultAlgorithms are the signature algorithms accepted by default.
+var DefaultAlgorithms = []string{
+	string(jose.HS256), string(jose.HS384), string(jose.HS512),
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+var defaultOptions = options{
+	rolesClaim:         "roles",
+	groupsClaim:        "groups",
+	algorithms:         DefaultAlgorithms,
+	clockSkew:          DefaultClockSkew,
+	refreshInterval:    DefaultRefreshInterval,
+	minRefreshInterval: DefaultMinRefreshInterval,
+	client:             http.DefaultClient,
+}
+
+type Option func(o *options)
+
+// WithIssuer sets the expected iss claim.
+func WithIssuer(iss string) Option {
+	return func(o *options) {
+		o.issuer = iss
+	}
+}
+
+// WithAudience sets the accepted aud claims: the token must contain at least one of them.
+func WithAudience(aud ...string) Option {
+	return func(o *options) {
+		o.audience = append(o.audience, aud...)
+	}
+}
+
+// WithClockSkew sets the leeway used when checking the exp, nbf and iat claims.
+// It defaults to DefaultClockSkew.
+func WithClockSkew(d time.Duration) Option {
+	return func(o *options) {
+		o.clockSkew = d
+	}
+}
+
+// WithRolesClaim sets the claim holding the roles of the principal, e.g. realm_access.roles.
+// Nested claims are separated by dots. It defaults to roles.
+func WithRolesClaim(name string) Option {
+	return func(o *options) {
+		o.rolesClaim = name
+	}
+}
+
+// WithGroupsClaim sets the claim holding the groups of the principal.
+// Nested claims are separated by dots. It defaults to groups.
+func WithGroupsClaim(name string) Option {
+	return func(o *options) {
+		o.groupsClaim = name
+	}
+}
+
+// WithAlgorithms restricts the accepted signature algorithms, e.g. RS256, ES256 or EdDSA.
+// It defaults to DefaultAlgorithms.
+func WithAlgorithms(algs ...string) Option {
+	return func(o *options) {
+		o.algorithms = algs
+	}
+}
+
+// WithSecret adds a shared secret used to verify the HS256, HS384 and HS512 signed tokens.
+func WithSecret(secret []byte) Option {
+	return func(o *options) {
+		o.keys = append(o.keys, jose.JSONWebKey{Key: secret})
+	}
+}
+
+// WithKeys adds public keys used to verify the tokens signatures:
+// *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey or jose.JSONWebKey.
+func WithKeys(keys ...any) Option {
+	return func(o *options) {
+		for _, v := range keys {
+			switch k := v.(type) {
+			case jose.JSONWebKey:
+				o.keys = append(o.keys, k)
+			case *jose.JSONWebKey:
+				o.keys = append(o.keys, *k)
+			default:
+				o.keys = append(o.keys, jose.JSONWebKey{Key: k})
+			}
+		}
+	}
+}
+
+// WithKeySetFile loads the keys from a JWKS file, reloading it when it changes.
+func WithKeySetFile(path string) Option {
+	return func(o *options) {
+		o.keySetFile = path
+	}
+}
+
+// WithKeySetConfig loads the keys from a JWKS provided by the config, reloading it when it changes.
+func WithKeySetConfig(conf config.Config) Option {
+	return func(o *options) {
+		o.keySetConfig = conf
+	}
+}
+
+// WithKeySetURL fetches the keys from a JWKS endpoint. The keys are cached and fetched again
+// every refresh interval, or when a token is signed with an unknown key.
+func WithKeySetURL(url string) Option {
+	return func(o *options) {
+		o.keySetURL = url
+	}
+}
+
+// WithDiscovery uses the OpenID Connect discovery document of the issuer to find the JWKS endpoint.
+// The issuer is also set as the expected iss claim.
+func WithDiscovery(issuer string) Option {
+	return func(o *options) {
+		o.discovery = issuer
+	}
+}
+
+// WithRefreshInterval sets the interval at which the remote key set is fetched again.
+// It defaults to DefaultRefreshInterval.
+func WithRefreshInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.refreshInterval = d
+	}
+}
+
+// WithMinRefreshInterval sets the minimum interval between two fetches of the remote key set
+// triggered by an unknown key id. It defaults to DefaultMinRefreshInterval.
+func WithMinRefreshInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.minRefreshInterval = d
+	}
+}
+
+// WithHTTPClient sets the client used to fetch the discovery document and the remote key set.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *options) {
+		o.client = c
+	}
+}
+
+type options struct {
+	issuer    string
+	audience  []string
+	clockSkew time.Duration
+
+	rolesClaim  string
+	groupsClaim string
+
+	algorithms []string
+
+	keys         []jose.JSONWebKey
+	keySetFile   string
+	keySetConfig config.Config
+	keySetURL    string
+	discovery    string
+
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+	client             *http.Client
+}
@@ -50,6 +50,18 @@ func WithX509Validators(validators ...X509Validator) Option {
 	}
 }
 
+// WithPeerCredsValidators authenticates the processes connected through a unix socket
+// served with the peercreds transport credentials.
+func WithPeerCredsValidators(validators ...PeerCredsValidator) Option {
+	var authFns []grpc_auth.AuthFunc
+	for _, v := range validators {
+		authFns = append(authFns, makePeerCredsAuthFunc(v))
+	}
+	return func(o *options) {
+		o.authFns = append(o.authFns, authFns...)
+	}
+}
+
 type options struct {
 	methods        []string
 	ignoredMethods []string
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc/peer"
+
+	"go.linka.cloud/grpc-toolkit/creds/peercreds"
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+// PeerCredsValidator validates the credentials of the process on the other side of a unix socket,
+// as provided by the peercreds transport credentials.
+type PeerCredsValidator func(ctx context.Context, creds *peercreds.Creds) (context.Context, error)
+
+func makePeerCredsAuthFunc(v PeerCredsValidator) grpc_auth.AuthFunc {
+	return func(ctx context.Context) (context.Context, error) {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return ctx, errors.Internalf("peer not found")
+		}
+		i, ok := p.AuthInfo.(peercreds.AuthInfo)
+		if !ok {
+			return ctx, errors.Unauthenticatedf("no peer credentials")
+		}
+		uid, ok := i.Creds.UserID()
+		if !ok {
+			return ctx, errors.Unauthenticatedf("unknown peer user id")
+		}
+		pr := &Principal{Subject: uid, Method: MethodPeerCreds, Attributes: map[string]string{"uid": uid}}
+		if pid, ok := i.Creds.PID(); ok {
+			pr.Attributes["pid"] = strconv.Itoa(pid)
+		}
+		ctx, err := v(ctx, &i.Creds)
+		return withPrincipal(ctx, err, pr)
+	}
+}
+
+// UIDValidator returns a validator accepting the processes running as one of the given user ids.
+func UIDValidator(uids ...string) PeerCredsValidator {
+	return func(ctx context.Context, creds *peercreds.Creds) (context.Context, error) {
+		uid, _ := creds.UserID()
+		if !slices.Contains(uids, uid) {
+			return ctx, errors.PermissionDeniedf("user id %s not allowed", uid)
+		}
+		return ctx, nil
+	}
+}
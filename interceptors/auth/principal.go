@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// The authentication methods of the principals set by the built-in validators.
+const (
+	MethodBasic     = "basic"
+	MethodBearer    = "bearer"
+	MethodX509      = "x509"
+	MethodPeerCreds = "peercreds"
+)
+
+// Principal is the authenticated caller, put in the context by the validators.
+type Principal struct {
+	// Subject identifies the caller, e.g. the user name or the token subject.
+	Subject string
+	// Method is the authentication method, e.g. MethodBasic or MethodX509.
+	Method string
+	// Groups are the groups the caller belongs to.
+	Groups []string
+	// Roles are the roles granted to the caller.
+	Roles []string
+	// Permissions are the permissions granted to the caller.
+	Permissions []string
+	// Attributes are additional method specific attributes, e.g. the token issuer.
+	Attributes map[string]string
+}
+
+// HasGroup reports whether the principal belongs to the given group.
+func (p *Principal) HasGroup(group string) bool {
+	return p != nil && slices.Contains(p.Groups, group)
+}
+
+// HasRole reports whether the principal has the given role.
+func (p *Principal) HasRole(role string) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// HasPermission reports whether the principal has the given permission.
+func (p *Principal) HasPermission(perm string) bool {
+	return p != nil && slices.Contains(p.Permissions, perm)
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of the context holding the principal.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom returns the principal authenticated by the validators.
+func PrincipalFrom(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// PrincipalFields returns the log fields describing the principal of the context, if any.
+func PrincipalFields(ctx context.Context) []any {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return []any{"auth.subject", p.Subject, "auth.method", p.Method}
+}
+
+// annotate attaches the principal to the context logger and to the current span.
+func annotate(ctx context.Context) context.Context {
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ctx
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("enduser.id", p.Subject), attribute.String("auth.method", p.Method))
+	return logger.Set(ctx, logger.C(ctx).WithFields(PrincipalFields(ctx)...))
+}
+
+// withPrincipal makes sure that the context returned by a validator holds a principal:
+// the validator one, with the authentication method set, or the default one.
+func withPrincipal(ctx context.Context, err error, def *Principal) (context.Context, error) {
+	if err != nil {
+		return ctx, err
+	}
+	p, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ContextWithPrincipal(ctx, def), nil
+	}
+	if p.Method != "" {
+		return ctx, nil
+	}
+	c := *p
+	c.Method = def.Method
+	return ContextWithPrincipal(ctx, &c), nil
+}
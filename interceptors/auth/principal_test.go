@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"go.linka.cloud/grpc-toolkit/creds/peercreds"
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func incoming(kv ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func TestPrincipal(t *testing.T) {
+	ctx, err := makeBasicAuthFunc(adminAuth)(incoming("authorization", BasicAuth("admin", "admin")))
+	require.NoError(t, err)
+	p, ok := PrincipalFrom(ctx)
+	require.True(t, ok)
+	assert.Equal(t, &Principal{Subject: "admin", Method: MethodBasic}, p)
+
+	ctx, err = makeTokenAuthFunc(tokenAuth)(incoming("authorization", "bearer token"))
+	require.NoError(t, err)
+	p, ok = PrincipalFrom(ctx)
+	require.True(t, ok)
+	assert.Equal(t, MethodBearer, p.Method)
+
+	// the validators principal is kept
+	ctx, err = makeTokenAuthFunc(func(ctx context.Context, token string) (context.Context, error) {
+		return ContextWithPrincipal(ctx, &Principal{Subject: "user", Roles: []string{"admin"}}), nil
+	})(incoming("authorization", "bearer token"))
+	require.NoError(t, err)
+	p, ok = PrincipalFrom(ctx)
+	require.True(t, ok)
+	assert.Equal(t, &Principal{Subject: "user", Method: MethodBearer, Roles: []string{"admin"}}, p)
+	assert.True(t, p.HasRole("admin"))
+	assert.Equal(t, []any{"auth.subject", "user", "auth.method", MethodBearer}, PrincipalFields(ctx))
+
+	ctx, err = makeX509AuthFunc(OUValidator("dev"))(tlsContext(t, "spiffe://example.org/sa/api", "dev"))
+	require.NoError(t, err)
+	p, ok = PrincipalFrom(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "spiffe://example.org/sa/api", p.Subject)
+	assert.Equal(t, MethodX509, p.Method)
+	assert.True(t, p.HasGroup("dev"))
+
+	_, ok = PrincipalFrom(context.Background())
+	assert.False(t, ok)
+}
+
+func TestPeerCredsPrincipal(t *testing.T) {
+	lis, err := net.Listen("unix", filepath.Join(t.TempDir(), "test.sock"))
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		c, err := net.Dial("unix", lis.Addr().String())
+		if err == nil {
+			defer c.Close()
+			_, _ = c.Read(make([]byte, 1))
+		}
+	}()
+	conn, err := lis.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	creds, err := peercreds.Get(conn)
+	require.NoError(t, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: peercreds.AuthInfo{Creds: *creds}})
+
+	uid := strconv.Itoa(os.Getuid())
+	ctx2, err := makePeerCredsAuthFunc(UIDValidator(uid))(ctx)
+	require.NoError(t, err)
+	p, ok := PrincipalFrom(ctx2)
+	require.True(t, ok)
+	assert.Equal(t, uid, p.Subject)
+	assert.Equal(t, MethodPeerCreds, p.Method)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), p.Attributes["pid"])
+
+	_, err = makePeerCredsAuthFunc(UIDValidator("-1"))(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.IsPermissionDenied(err))
+}
@@ -17,7 +17,8 @@ func makeTokenAuthFunc(v TokenValidator) grpc_auth.AuthFunc {
 		if err != nil {
 			return ctx, err
 		}
-		return v(ctx, a)
+		ctx, err = v(ctx, a)
+		return withPrincipal(ctx, err, &Principal{Method: MethodBearer})
 	}
 }
 
@@ -2,6 +2,11 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
 
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"google.golang.org/grpc/credentials"
@@ -10,19 +15,25 @@ import (
 	"go.linka.cloud/grpc-toolkit/errors"
 )
 
-type X509Validator func(ctx context.Context, sans []string) (context.Context, error)
+// X509Identity is the identity of a client authenticated with a verified certificate.
+type X509Identity struct {
+	// Certificate is the client leaf certificate.
+	Certificate *x509.Certificate
+	// VerifiedChains are the verified chains, starting with the leaf certificate.
+	VerifiedChains [][]*x509.Certificate
 
-// func _(ctx context.Context) {
-// 	p, ok := peer.FromContext(ctx)
-// 	if !ok {
-// 		return
-// 	}
-// 	i, ok := p.AuthInfo.(credentials.TLSInfo)
-// 	if !ok {
-// 		return
-// 	}
-// 	i.State.VerifiedChains
-// }
+	DNSNames       []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+	EmailAddresses []string
+	// SPIFFEID is the SPIFFE ID of the client, i.e. its spiffe:// URI SAN, if any.
+	SPIFFEID *url.URL
+
+	CommonName          string
+	OrganizationalUnits []string
+}
+
+type X509Validator func(ctx context.Context, id *X509Identity) (context.Context, error)
 
 func makeX509AuthFunc(v X509Validator) grpc_auth.AuthFunc {
 	return func(ctx context.Context) (context.Context, error) {
@@ -37,13 +48,78 @@ func makeX509AuthFunc(v X509Validator) grpc_auth.AuthFunc {
 		if !i.State.HandshakeComplete {
 			return ctx, errors.Unauthenticatedf("handshake not complete")
 		}
-		var sans []string
-		for _, v := range i.State.VerifiedChains {
-			if len(v) == 0 {
-				continue
+		if len(i.State.VerifiedChains) == 0 || len(i.State.VerifiedChains[0]) == 0 {
+			return ctx, errors.Unauthenticatedf("no verified client certificate")
+		}
+		id := newX509Identity(i.State.VerifiedChains)
+		ctx, err := v(ctx, id)
+		return withPrincipal(ctx, err, id.principal())
+	}
+}
+
+func newX509Identity(chains [][]*x509.Certificate) *X509Identity {
+	c := chains[0][0]
+	id := &X509Identity{
+		Certificate:         c,
+		VerifiedChains:      chains,
+		DNSNames:            c.DNSNames,
+		IPAddresses:         c.IPAddresses,
+		URIs:                c.URIs,
+		EmailAddresses:      c.EmailAddresses,
+		CommonName:          c.Subject.CommonName,
+		OrganizationalUnits: c.Subject.OrganizationalUnit,
+	}
+	for _, v := range c.URIs {
+		if v.Scheme == "spiffe" {
+			id.SPIFFEID = v
+			break
+		}
+	}
+	return id
+}
+
+// principal returns the principal of the identity: its subject is the SPIFFE ID if any,
+// or the common name, and its groups are the organizational units.
+func (id *X509Identity) principal() *Principal {
+	p := &Principal{
+		Subject:    id.CommonName,
+		Method:     MethodX509,
+		Groups:     id.OrganizationalUnits,
+		Attributes: map[string]string{"cn": id.CommonName},
+	}
+	if id.SPIFFEID != nil {
+		p.Subject = id.SPIFFEID.String()
+		p.Attributes["spiffe_id"] = p.Subject
+	}
+	return p
+}
+
+// SPIFFEValidator returns a validator accepting the certificates whose SPIFFE ID is one of the given ids,
+// e.g. spiffe://example.org/ns/default/sa/api, or matches a wildcard, e.g. spiffe://example.org/*.
+func SPIFFEValidator(ids ...string) X509Validator {
+	return func(ctx context.Context, id *X509Identity) (context.Context, error) {
+		if id.SPIFFEID == nil {
+			return ctx, errors.Unauthenticatedf("no SPIFFE ID")
+		}
+		s := id.SPIFFEID.String()
+		for _, v := range ids {
+			if v == s || (strings.HasSuffix(v, "*") && strings.HasPrefix(s, strings.TrimSuffix(v, "*"))) {
+				return ctx, nil
+			}
+		}
+		return ctx, errors.PermissionDeniedf("SPIFFE ID %s not allowed", s)
+	}
+}
+
+// OUValidator returns a validator accepting the certificates whose subject has one of the given
+// organizational units.
+func OUValidator(ous ...string) X509Validator {
+	return func(ctx context.Context, id *X509Identity) (context.Context, error) {
+		for _, v := range id.OrganizationalUnits {
+			if slices.Contains(ous, v) {
+				return ctx, nil
 			}
-			sans = append(sans, v[0].PermittedDNSDomains...)
 		}
-		return v(ctx, sans)
+		return ctx, errors.PermissionDeniedf("organizational unit not allowed")
 	}
 }
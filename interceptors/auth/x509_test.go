@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func newCert(t *testing.T, tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Minute)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	b, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	c, err := x509.ParseCertificate(b)
+	require.NoError(t, err)
+	return c, key
+}
+
+func tlsContext(t *testing.T, spiffe string, ous ...string) context.Context {
+	ca, caKey := newCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	u, err := url.Parse(spiffe)
+	require.NoError(t, err)
+	leaf, _ := newCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "client", OrganizationalUnit: ous},
+		DNSNames:    []string{"client.example.org"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:        []*url.URL{{Scheme: "https", Host: "example.org"}, u},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			HandshakeComplete: true,
+			VerifiedChains:    [][]*x509.Certificate{{leaf, ca}},
+		}},
+	})
+}
+
+func TestX509Identity(t *testing.T) {
+	ctx := tlsContext(t, "spiffe://example.org/ns/default/sa/api", "dev", "ops")
+	var id *X509Identity
+	_, err := makeX509AuthFunc(func(ctx context.Context, i *X509Identity) (context.Context, error) {
+		id = i
+		return ctx, nil
+	})(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"client.example.org"}, id.DNSNames)
+	assert.True(t, id.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")))
+	assert.Len(t, id.URIs, 2)
+	require.NotNil(t, id.SPIFFEID)
+	assert.Equal(t, "spiffe://example.org/ns/default/sa/api", id.SPIFFEID.String())
+	assert.Equal(t, "client", id.CommonName)
+	assert.Equal(t, []string{"dev", "ops"}, id.OrganizationalUnits)
+	assert.Len(t, id.VerifiedChains[0], 2)
+	assert.Equal(t, "ca", id.VerifiedChains[0][1].Subject.CommonName)
+
+	_, err = makeX509AuthFunc(func(ctx context.Context, i *X509Identity) (context.Context, error) {
+		return ctx, nil
+	})(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{HandshakeComplete: true}}}))
+	require.Error(t, err)
+	assert.True(t, errors.IsUnauthenticated(err))
+}
+
+func TestX509Validators(t *testing.T) {
+	ctx := tlsContext(t, "spiffe://example.org/ns/default/sa/api", "dev")
+	check := func(v X509Validator) error {
+		_, err := makeX509AuthFunc(v)(ctx)
+		return err
+	}
+	assert.NoError(t, check(SPIFFEValidator("spiffe://example.org/ns/default/sa/api")))
+	assert.NoError(t, check(SPIFFEValidator("spiffe://other.org/*", "spiffe://example.org/ns/default/*")))
+	err := check(SPIFFEValidator("spiffe://example.org/ns/default/sa/web", "spiffe://example.org/ns/prod/*"))
+	require.Error(t, err)
+	assert.True(t, errors.IsPermissionDenied(err))
+
+	assert.NoError(t, check(OUValidator("ops", "dev")))
+	assert.Error(t, check(OUValidator("ops")))
+}
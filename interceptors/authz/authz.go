@@ -0,0 +1,130 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+)
+
+const (
+	// Domain is the ErrorInfo domain of the authorization errors.
+	Domain = "grpc-toolkit.linka.cloud"
+	// ReasonMissingRole is the ErrorInfo reason when the principal has none of the required roles.
+	ReasonMissingRole = "MISSING_ROLE"
+	// ReasonMissingPermission is the ErrorInfo reason when the principal lacks a required permission.
+	ReasonMissingPermission = "MISSING_PERMISSION"
+)
+
+// NewServerInterceptors returns server interceptors authorizing the calls against the policy
+// of the method, either given with WithPolicies or with the (linka.authz) method option.
+//
+// The calls without principal fail with codes.Unauthenticated unless the policy is public,
+// the calls not satisfying the policy fail with codes.PermissionDenied along with ErrorInfo details.
+// The interceptors must be installed after the auth interceptors.
+func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	return &authz{o: o}
+}
+
+type authz struct {
+	o options
+}
+
+func (a *authz) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *authz) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *authz) authorize(ctx context.Context, method string) error {
+	p := a.policy(method)
+	if p == nil || p.GetPublic() {
+		return nil
+	}
+	pr, ok := a.o.principalFunc(ctx)
+	if !ok {
+		return errors.Unauthenticatedf("missing principal")
+	}
+	if roles := p.GetRoles(); len(roles) != 0 && !hasAny(pr, roles) {
+		return denied(ReasonMissingRole, method, map[string]string{"roles": strings.Join(roles, ",")})
+	}
+	for _, v := range p.GetPermissions() {
+		if !pr.HasPermission(v) {
+			return denied(ReasonMissingPermission, method, map[string]string{"permission": v})
+		}
+	}
+	return nil
+}
+
+// policy returns the policy of the method: the one given with the options for its exact name,
+// the method option, the most specific wildcard given with the options, or the default policy.
+// The wildcards never override the method own policy.
+func (a *authz) policy(method string) *AuthzPolicy {
+	if p, ok := a.o.policies[method]; ok {
+		return p
+	}
+	if p, ok := methods.Option[*AuthzPolicy](method, E_Authz); ok {
+		return p
+	}
+	var (
+		p       *AuthzPolicy
+		pattern string
+	)
+	for k, v := range a.o.policies {
+		if methods.Match(k, method) && (p == nil || specificity(k) > specificity(pattern)) {
+			p, pattern = v, k
+		}
+	}
+	if p != nil {
+		return p
+	}
+	return a.o.defaultPolicy
+}
+
+// specificity ranks the patterns: exact names first, then the longest wildcards.
+func specificity(pattern string) int {
+	if !strings.HasSuffix(pattern, "*") {
+		return len(pattern) + 1
+	}
+	return len(pattern) - 1
+}
+
+func hasAny(p *auth.Principal, roles []string) bool {
+	for _, v := range roles {
+		if p.HasRole(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func denied(reason, method string, md map[string]string) error {
+	md["method"] = method
+	return errors.PermissionDeniedd(
+		fmt.Errorf("permission denied for %s", method),
+		&errdetails.ErrorInfo{Reason: reason, Domain: Domain, Metadata: md},
+	)
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: interceptors/authz/authz.proto
+
+package authz
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuthzPolicy describes the principals allowed to call a method.
+type AuthzPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// public allows the calls without principal.
+	Public bool `protobuf:"varint,1,opt,name=public,proto3" json:"public,omitempty"`
+	// roles are the roles allowed to call the method: the principal must have at least one of them.
+	Roles []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	// permissions are the permissions required to call the method: the principal must have all of them.
+	Permissions   []string `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthzPolicy) Reset() {
+	*x = AuthzPolicy{}
+	mi := &file_interceptors_authz_authz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthzPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthzPolicy) ProtoMessage() {}
+
+func (x *AuthzPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_interceptors_authz_authz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthzPolicy.ProtoReflect.Descriptor instead.
+func (*AuthzPolicy) Descriptor() ([]byte, []int) {
+	return file_interceptors_authz_authz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthzPolicy) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *AuthzPolicy) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *AuthzPolicy) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+var file_interceptors_authz_authz_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*AuthzPolicy)(nil),
+		Field:         51003,
+		Name:          "linka.authz",
+		Tag:           "bytes,51003,opt,name=authz",
+		Filename:      "interceptors/authz/authz.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// authz is the policy enforced by the authz interceptors.
+	//
+	// optional linka.AuthzPolicy authz = 51003;
+	E_Authz = &file_interceptors_authz_authz_proto_extTypes[0]
+)
+
+var File_interceptors_authz_authz_proto protoreflect.FileDescriptor
+
+const file_interceptors_authz_authz_proto_rawDesc = "" +
+	"\n" +
+	"\x1einterceptors/authz/authz.proto\x12\x05linka\x1a google/protobuf/descriptor.proto\"]\n" +
+	"\vAuthzPolicy\x12\x16\n" +
+	"\x06public\x18\x01 \x01(\bR\x06public\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions:J\n" +
+	"\x05authz\x12\x1e.google.protobuf.MethodOptions\x18\xbb\x8e\x03 \x01(\v2\x12.linka.AuthzPolicyR\x05authzB0Z.go.linka.cloud/grpc-toolkit/interceptors/authzb\x06proto3"
+
+var (
+	file_interceptors_authz_authz_proto_rawDescOnce sync.Once
+	file_interceptors_authz_authz_proto_rawDescData []byte
+)
+
+func file_interceptors_authz_authz_proto_rawDescGZIP() []byte {
+	file_interceptors_authz_authz_proto_rawDescOnce.Do(func() {
+		file_interceptors_authz_authz_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_interceptors_authz_authz_proto_rawDesc), len(file_interceptors_authz_authz_proto_rawDesc)))
+	})
+	return file_interceptors_authz_authz_proto_rawDescData
+}
+
+var file_interceptors_authz_authz_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_interceptors_authz_authz_proto_goTypes = []any{
+	(*AuthzPolicy)(nil),                // 0: linka.AuthzPolicy
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_interceptors_authz_authz_proto_depIdxs = []int32{
+	1, // 0: linka.authz:extendee -> google.protobuf.MethodOptions
+	0, // 1: linka.authz:type_name -> linka.AuthzPolicy
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_interceptors_authz_authz_proto_init() }
+func file_interceptors_authz_authz_proto_init() {
+	if File_interceptors_authz_authz_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interceptors_authz_authz_proto_rawDesc), len(file_interceptors_authz_authz_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_interceptors_authz_authz_proto_goTypes,
+		DependencyIndexes: file_interceptors_authz_authz_proto_depIdxs,
+		MessageInfos:      file_interceptors_authz_authz_proto_msgTypes,
+		ExtensionInfos:    file_interceptors_authz_authz_proto_extTypes,
+	}.Build()
+	File_interceptors_authz_authz_proto = out.File
+	file_interceptors_authz_authz_proto_goTypes = nil
+	file_interceptors_authz_authz_proto_depIdxs = nil
+}
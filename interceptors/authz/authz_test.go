@@ -0,0 +1,135 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+func init() {
+	opts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(opts, E_Authz, &AuthzPolicy{Roles: []string{"admin"}})
+	method := func(name string, opts *descriptorpb.MethodOptions) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(".google.protobuf.Empty"),
+			OutputType: proto.String(".google.protobuf.Empty"),
+			Options:    opts,
+		}
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("interceptors/authz/authz_test.proto"),
+		Package:    proto.String("authz.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/empty.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("Service"),
+			Method: []*descriptorpb.MethodDescriptorProto{method("Admin", opts), method("Other", nil)},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+}
+
+func call(ctx context.Context, i interceptors.ServerInterceptors, method string) error {
+	_, err := i.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func reason(t *testing.T, err error) string {
+	require.Error(t, err)
+	require.True(t, errors.IsPermissionDenied(err))
+	for _, v := range status.Convert(err).Details() {
+		if i, ok := v.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, Domain, i.Domain)
+			return i.Reason
+		}
+	}
+	t.Fatal("missing ErrorInfo")
+	return ""
+}
+
+func principal(roles []string, perms ...string) context.Context {
+	return auth.ContextWithPrincipal(context.Background(), &auth.Principal{Subject: "user", Roles: roles, Permissions: perms})
+}
+
+func TestMethodOption(t *testing.T) {
+	i := NewServerInterceptors()
+	assert.NoError(t, call(principal([]string{"admin"}), i, "/authz.test.Service/Admin"))
+	assert.Equal(t, ReasonMissingRole, reason(t, call(principal([]string{"user"}), i, "/authz.test.Service/Admin")))
+	err := call(context.Background(), i, "/authz.test.Service/Admin")
+	require.Error(t, err)
+	assert.True(t, errors.IsUnauthenticated(err))
+	// no policy
+	assert.NoError(t, call(context.Background(), i, "/authz.test.Service/Other"))
+
+	i = NewServerInterceptors(WithDefaultPolicy(&AuthzPolicy{}))
+	assert.NoError(t, call(principal(nil), i, "/authz.test.Service/Other"))
+	assert.Error(t, call(context.Background(), i, "/authz.test.Service/Other"))
+}
+
+func TestPolicies(t *testing.T) {
+	i := NewServerInterceptors(WithPolicies(map[string]*AuthzPolicy{
+		"*":                         {Roles: []string{"admin"}},
+		"/pkg.Svc/*":                {Roles: []string{"admin", "user"}, Permissions: []string{"svc.read"}},
+		"/pkg.Svc/Write":            {Permissions: []string{"svc.read", "svc.write"}},
+		"/pkg.Svc/Health":           {Public: true},
+		"/authz.test.Service/Admin": {Roles: []string{"ops"}},
+	}))
+
+	assert.NoError(t, call(principal([]string{"user"}, "svc.read"), i, "/pkg.Svc/Read"))
+	assert.Equal(t, ReasonMissingPermission, reason(t, call(principal([]string{"user"}), i, "/pkg.Svc/Read")))
+	assert.Equal(t, ReasonMissingRole, reason(t, call(principal([]string{"guest"}, "svc.read"), i, "/pkg.Svc/Read")))
+
+	assert.NoError(t, call(principal(nil, "svc.read", "svc.write"), i, "/pkg.Svc/Write"))
+	assert.Equal(t, ReasonMissingPermission, reason(t, call(principal([]string{"admin"}, "svc.read"), i, "/pkg.Svc/Write")))
+
+	assert.NoError(t, call(context.Background(), i, "/pkg.Svc/Health"))
+	assert.NoError(t, call(principal([]string{"admin"}), i, "/other.Svc/Get"))
+	assert.Equal(t, ReasonMissingRole, reason(t, call(principal([]string{"user"}), i, "/other.Svc/Get")))
+
+	// the exact policies override the method option
+	assert.NoError(t, call(principal([]string{"ops"}), i, "/authz.test.Service/Admin"))
+	assert.Equal(t, ReasonMissingRole, reason(t, call(principal([]string{"admin"}), i, "/authz.test.Service/Admin")))
+
+	// the wildcards do not override the method option
+	i = NewServerInterceptors(WithPolicies(map[string]*AuthzPolicy{
+		"*":                     {Public: true},
+		"/authz.test.Service/*": {Roles: []string{"user"}},
+	}))
+	assert.Equal(t, ReasonMissingRole, reason(t, call(principal([]string{"user"}), i, "/authz.test.Service/Admin")))
+	assert.NoError(t, call(principal([]string{"admin"}), i, "/authz.test.Service/Admin"))
+	assert.NoError(t, call(principal([]string{"user"}), i, "/authz.test.Service/Other"))
+	assert.NoError(t, call(context.Background(), i, "/pkg.Svc/Get"))
+}
+
+func TestStream(t *testing.T) {
+	i := NewServerInterceptors(WithPolicies(map[string]*AuthzPolicy{"*": {Roles: []string{"admin"}}}))
+	handler := func(srv any, ss grpc.ServerStream) error { return nil }
+	info := &grpc.StreamServerInfo{FullMethod: "/pkg.Svc/Watch"}
+
+	ss := interceptors.NewContextServerStream(principal([]string{"admin"}), nil)
+	assert.NoError(t, i.StreamServerInterceptor()(nil, ss, info, handler))
+	ss = interceptors.NewContextServerStream(principal([]string{"user"}), nil)
+	assert.Equal(t, ReasonMissingRole, reason(t, i.StreamServerInterceptor()(nil, ss, info, handler)))
+}
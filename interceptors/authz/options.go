@@ -0,0 +1,54 @@
+package authz
+
+import (
+	"context"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+// PrincipalFunc returns the principal of the call.
+type PrincipalFunc func(ctx context.Context) (*auth.Principal, bool)
+
+var defaultOptions = options{
+	principalFunc: auth.PrincipalFrom,
+}
+
+type Option func(*options)
+
+// WithPolicies sets the policies of the methods.
+// The keys are fully qualified method names, e.g. /helloworld.Greeter/SayHello,
+// or wildcards, e.g. /helloworld.Greeter/* or /helloworld.*. The most specific pattern wins.
+// The fully qualified names override the (linka.authz) method option, the wildcards only apply
+// to the methods without it.
+func WithPolicies(policies map[string]*AuthzPolicy) Option {
+	return func(o *options) {
+		if o.policies == nil {
+			o.policies = make(map[string]*AuthzPolicy, len(policies))
+		}
+		for k, v := range policies {
+			o.policies[k] = v
+		}
+	}
+}
+
+// WithDefaultPolicy sets the policy of the methods without policy.
+// By default, these methods are not authorized by the interceptors.
+func WithDefaultPolicy(p *AuthzPolicy) Option {
+	return func(o *options) {
+		o.defaultPolicy = p
+	}
+}
+
+// WithPrincipalFunc sets the function returning the principal of the call.
+// It defaults to auth.PrincipalFrom.
+func WithPrincipalFunc(f PrincipalFunc) Option {
+	return func(o *options) {
+		o.principalFunc = f
+	}
+}
+
+type options struct {
+	policies      map[string]*AuthzPolicy
+	defaultPolicy *AuthzPolicy
+	principalFunc PrincipalFunc
+}
@@ -15,7 +15,7 @@ import (
 type ban struct {
 	s     *badactor.Studio
 	rules map[codes.Code]Rule
-	actor func(ctx context.Context) (string, bool, error)
+	actor ActorFunc
 }
 
 func NewInterceptors(opts ...Option) interceptors.ServerInterceptors {
@@ -7,6 +7,8 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
 )
 
 const (
@@ -40,6 +42,10 @@ var (
 	}
 )
 
+// ActorFunc returns the name of the actor doing the call, and whether it could be identified.
+type ActorFunc func(ctx context.Context) (name string, found bool, err error)
+
+// DefaultActorFunc identifies the actor by its peer address host.
 func DefaultActorFunc(ctx context.Context) (string, bool, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
@@ -51,6 +57,15 @@ func DefaultActorFunc(ctx context.Context) (string, bool, error) {
 	return p.Addr.String(), true, nil
 }
 
+// PrincipalActorFunc identifies the actor by the subject of the auth principal, and falls back
+// to DefaultActorFunc when the call has no principal, e.g. when its authentication failed.
+func PrincipalActorFunc(ctx context.Context) (string, bool, error) {
+	if p, ok := auth.PrincipalFrom(ctx); ok && p.Subject != "" {
+		return p.Method + ":" + p.Subject, true, nil
+	}
+	return DefaultActorFunc(ctx)
+}
+
 type Option func(*options)
 
 func WithCapacity(cap int32) Option {
@@ -71,7 +86,7 @@ func WithReaperInterval(interval time.Duration) Option {
 	}
 }
 
-func WithActorFunc(f func(context.Context) (name string, found bool, err error)) Option {
+func WithActorFunc(f ActorFunc) Option {
 	return func(o *options) {
 		o.actorFunc = f
 	}
@@ -93,7 +108,7 @@ type options struct {
 	cap                 int32
 	rules               []Rule
 	reaperInterval      time.Duration
-	actorFunc           func(ctx context.Context) (name string, found bool, err error)
+	actorFunc           ActorFunc
 	defaultCallback     ActionCallback
 	defaultJailDuration time.Duration
 }
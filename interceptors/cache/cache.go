@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/callkey"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// NewInterceptors returns interceptors caching unary responses.
+//
+// The client interceptors serve the responses from the store while they are fresh.
+// The responses are cached for the TTL configured with WithTTL or with the (linka.cache) method option,
+// bounded by the max-age hint sent by the server. The server hints alone never enable the caching,
+// so that a server cannot make the clients serve stale responses they did not opt in for.
+// The client can control the lookups with the cache-control metadata key, see ControlKey.
+//
+// The server interceptors send the configured TTLs to the clients as cache-control hints.
+// Streams are not cached.
+func NewInterceptors(opts ...Option) interceptors.Interceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	if o.store == nil {
+		o.store = NewLRU(DefaultMaxEntries, DefaultMaxBytes)
+	}
+	return &cache{o: o}
+}
+
+type cache struct {
+	o options
+}
+
+func (c *cache) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if ttl, ok := c.ttl(info.FullMethod); ok && ttl > 0 {
+			if err := grpc.SetHeader(ctx, metadata.Pairs(ControlKey, maxAge(ttl))); err != nil {
+				logger.C(ctx).Debugf("failed to set cache hint: %v", err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (c *cache) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+}
+
+func (c *cache) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		r, ok := reply.(proto.Message)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ctrl := parseControl(md)
+		if ctrl.noStore {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ttl, ok := c.ttl(method)
+		if !ok || ttl <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		key, ok := callkey.Key(ctx, callkey.Target(cc), method, req, c.o.metadataKeys...)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if !ctrl.noCache {
+			if e, found := c.o.store.Get(ctx, key); found && (ctrl.maxAge == nil || time.Since(e.Created) <= *ctrl.maxAge) {
+				if err := proto.Unmarshal(e.Value, r); err == nil {
+					setHeader(opts, e.Header)
+					return nil
+				}
+				c.o.store.Delete(ctx, key)
+			}
+		}
+		var header metadata.MD
+		if err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...); err != nil {
+			return err
+		}
+		hint := parseControl(header)
+		if hint.noStore || hint.noCache {
+			return nil
+		}
+		if hint.maxAge != nil && *hint.maxAge < ttl {
+			ttl = *hint.maxAge
+		}
+		if ttl <= 0 {
+			return nil
+		}
+		b, err := proto.Marshal(r)
+		if err != nil {
+			return nil
+		}
+		now := time.Now()
+		c.o.store.Set(ctx, key, &Entry{Value: b, Header: header.Copy(), Created: now, Expires: now.Add(ttl)})
+		return nil
+	}
+}
+
+func (c *cache) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// setHeader fills the header call options with md, as the invoker would.
+func setHeader(opts []grpc.CallOption, md metadata.MD) {
+	for _, v := range opts {
+		if h, ok := v.(grpc.HeaderCallOption); ok {
+			*h.HeaderAddr = md.Copy()
+		}
+	}
+}
+
+func (c *cache) ttl(method string) (time.Duration, bool) {
+	for _, v := range c.o.ttls {
+		if methods.MatchAny(v.methods, method) {
+			return v.ttl, true
+		}
+	}
+	p, ok := methods.Option[*CachePolicy](method, E_Cache)
+	if !ok || p.GetTtl() == nil {
+		return 0, false
+	}
+	return p.GetTtl().AsDuration(), true
+}
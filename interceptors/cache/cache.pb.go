@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: interceptors/cache/cache.proto
+
+package cache
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CachePolicy describes how the responses of a method can be cached.
+type CachePolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ttl is the duration for which a response can be served from the cache.
+	Ttl           *durationpb.Duration `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CachePolicy) Reset() {
+	*x = CachePolicy{}
+	mi := &file_interceptors_cache_cache_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachePolicy) ProtoMessage() {}
+
+func (x *CachePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_interceptors_cache_cache_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachePolicy.ProtoReflect.Descriptor instead.
+func (*CachePolicy) Descriptor() ([]byte, []int) {
+	return file_interceptors_cache_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CachePolicy) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+var file_interceptors_cache_cache_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*CachePolicy)(nil),
+		Field:         51002,
+		Name:          "linka.cache",
+		Tag:           "bytes,51002,opt,name=cache",
+		Filename:      "interceptors/cache/cache.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// cache enables the caching of the method responses by the cache interceptors.
+	//
+	// optional linka.CachePolicy cache = 51002;
+	E_Cache = &file_interceptors_cache_cache_proto_extTypes[0]
+)
+
+var File_interceptors_cache_cache_proto protoreflect.FileDescriptor
+
+const file_interceptors_cache_cache_proto_rawDesc = "" +
+	"\n" +
+	"\x1einterceptors/cache/cache.proto\x12\x05linka\x1a google/protobuf/descriptor.proto\x1a\x1egoogle/protobuf/duration.proto\":\n" +
+	"\vCachePolicy\x12+\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03ttl:J\n" +
+	"\x05cache\x12\x1e.google.protobuf.MethodOptions\x18\xba\x8e\x03 \x01(\v2\x12.linka.CachePolicyR\x05cacheB0Z.go.linka.cloud/grpc-toolkit/interceptors/cacheb\x06proto3"
+
+var (
+	file_interceptors_cache_cache_proto_rawDescOnce sync.Once
+	file_interceptors_cache_cache_proto_rawDescData []byte
+)
+
+func file_interceptors_cache_cache_proto_rawDescGZIP() []byte {
+	file_interceptors_cache_cache_proto_rawDescOnce.Do(func() {
+		file_interceptors_cache_cache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_interceptors_cache_cache_proto_rawDesc), len(file_interceptors_cache_cache_proto_rawDesc)))
+	})
+	return file_interceptors_cache_cache_proto_rawDescData
+}
+
+var file_interceptors_cache_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_interceptors_cache_cache_proto_goTypes = []any{
+	(*CachePolicy)(nil),                // 0: linka.CachePolicy
+	(*durationpb.Duration)(nil),        // 1: google.protobuf.Duration
+	(*descriptorpb.MethodOptions)(nil), // 2: google.protobuf.MethodOptions
+}
+var file_interceptors_cache_cache_proto_depIdxs = []int32{
+	1, // 0: linka.CachePolicy.ttl:type_name -> google.protobuf.Duration
+	2, // 1: linka.cache:extendee -> google.protobuf.MethodOptions
+	0, // 2: linka.cache:type_name -> linka.CachePolicy
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	2, // [2:3] is the sub-list for extension type_name
+	1, // [1:2] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_interceptors_cache_cache_proto_init() }
+func file_interceptors_cache_cache_proto_init() {
+	if File_interceptors_cache_cache_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interceptors_cache_cache_proto_rawDesc), len(file_interceptors_cache_cache_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_interceptors_cache_cache_proto_goTypes,
+		DependencyIndexes: file_interceptors_cache_cache_proto_depIdxs,
+		MessageInfos:      file_interceptors_cache_cache_proto_msgTypes,
+		ExtensionInfos:    file_interceptors_cache_cache_proto_extTypes,
+	}.Build()
+	File_interceptors_cache_cache_proto = out.File
+	file_interceptors_cache_cache_proto_goTypes = nil
+	file_interceptors_cache_cache_proto_depIdxs = nil
+}
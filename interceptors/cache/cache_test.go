@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func invoker(calls *atomic.Int32, header metadata.MD) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		n := calls.Add(1)
+		for _, v := range opts {
+			if h, ok := v.(grpc.HeaderCallOption); ok {
+				*h.HeaderAddr = header
+			}
+		}
+		reply.(*wrapperspb.Int32Value).Value = n
+		return nil
+	}
+}
+
+func call(t *testing.T, ctx context.Context, i grpc.UnaryClientInterceptor, method string, inv grpc.UnaryInvoker) int32 {
+	reply := &wrapperspb.Int32Value{}
+	require.NoError(t, i(ctx, method, wrapperspb.String("req"), reply, nil, inv))
+	return reply.Value
+}
+
+func TestClientCache(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	inv := invoker(&calls, nil)
+	i := NewInterceptors(WithTTL(time.Hour, "/test.Service/Get")).UnaryClientInterceptor()
+
+	assert.Equal(t, int32(1), call(t, ctx, i, "/test.Service/Get", inv))
+	assert.Equal(t, int32(1), call(t, ctx, i, "/test.Service/Get", inv))
+	assert.Equal(t, int32(2), call(t, ctx, i, "/test.Service/List", inv))
+	assert.Equal(t, int32(3), call(t, ctx, i, "/test.Service/List", inv))
+
+	// different authorization, different key
+	assert.Equal(t, int32(4), call(t, metadata.AppendToOutgoingContext(ctx, "authorization", "other"), i, "/test.Service/Get", inv))
+
+	// no-cache skips the lookup but stores the response
+	assert.Equal(t, int32(5), call(t, metadata.AppendToOutgoingContext(ctx, ControlKey, "no-cache"), i, "/test.Service/Get", inv))
+	assert.Equal(t, int32(5), call(t, ctx, i, "/test.Service/Get", inv))
+
+	// no-store skips the cache entirely
+	assert.Equal(t, int32(6), call(t, metadata.AppendToOutgoingContext(ctx, ControlKey, "no-store"), i, "/test.Service/Get", inv))
+	assert.Equal(t, int32(5), call(t, ctx, i, "/test.Service/Get", inv))
+
+	// max-age=0 requires a fresh response
+	assert.Equal(t, int32(7), call(t, metadata.AppendToOutgoingContext(ctx, ControlKey, "max-age=0"), i, "/test.Service/Get", inv))
+}
+
+func TestClientCacheServerHint(t *testing.T) {
+	ctx := context.Background()
+	var calls atomic.Int32
+	i := NewInterceptors(WithTTL(time.Hour, "/test.Service/Get", "/test.Service/List")).UnaryClientInterceptor()
+
+	// the hints alone do not enable the caching
+	inv := invoker(&calls, metadata.Pairs(ControlKey, "max-age=3600"))
+	assert.Equal(t, int32(1), call(t, ctx, NewInterceptors().UnaryClientInterceptor(), "/test.Service/Get", inv))
+	assert.Equal(t, int32(2), call(t, ctx, NewInterceptors().UnaryClientInterceptor(), "/test.Service/Get", inv))
+
+	assert.Equal(t, int32(3), call(t, ctx, i, "/test.Service/Get", inv))
+	// the cache hits return the response header
+	var header metadata.MD
+	reply := &wrapperspb.Int32Value{}
+	require.NoError(t, i(ctx, "/test.Service/Get", wrapperspb.String("req"), reply, nil, inv, grpc.Header(&header)))
+	assert.Equal(t, int32(3), reply.Value)
+	assert.Equal(t, []string{"max-age=3600"}, header.Get(ControlKey))
+
+	inv = invoker(&calls, metadata.Pairs(ControlKey, "no-store"))
+	assert.Equal(t, int32(4), call(t, ctx, i, "/test.Service/List", inv))
+	assert.Equal(t, int32(5), call(t, ctx, i, "/test.Service/List", inv))
+
+	// the hints bound the client ttl
+	inv = invoker(&calls, metadata.Pairs(ControlKey, "max-age=0"))
+	assert.Equal(t, int32(6), call(t, ctx, i, "/test.Service/List", inv))
+	assert.Equal(t, int32(7), call(t, ctx, i, "/test.Service/List", inv))
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	entry := func(v string, ttl time.Duration) *Entry {
+		return &Entry{Value: []byte(v), Created: now, Expires: now.Add(ttl)}
+	}
+
+	s := NewLRU(2, 0)
+	s.Set(ctx, "a", entry("a", time.Hour))
+	s.Set(ctx, "b", entry("b", time.Hour))
+	_, ok := s.Get(ctx, "a")
+	assert.True(t, ok)
+	s.Set(ctx, "c", entry("c", time.Hour))
+	_, ok = s.Get(ctx, "b")
+	assert.False(t, ok, "b should have been evicted")
+	_, ok = s.Get(ctx, "a")
+	assert.True(t, ok)
+
+	s = NewLRU(0, 4)
+	s.Set(ctx, "a", entry("aa", time.Hour))
+	s.Set(ctx, "b", entry("bb", time.Hour))
+	s.Set(ctx, "c", entry("cc", time.Hour))
+	_, ok = s.Get(ctx, "a")
+	assert.False(t, ok, "a should have been evicted")
+	s.Set(ctx, "d", entry("too large", time.Hour))
+	_, ok = s.Get(ctx, "d")
+	assert.False(t, ok)
+
+	s.Set(ctx, "e", entry("e", -time.Second))
+	_, ok = s.Get(ctx, "e")
+	assert.False(t, ok, "e should have expired")
+}
+
+func TestClientCacheTargets(t *testing.T) {
+	var calls atomic.Int32
+	inv := invoker(&calls, nil)
+	i := NewInterceptors(WithTTL(time.Hour, "/test.Service/Get")).UnaryClientInterceptor()
+	var ccs []*grpc.ClientConn
+	for _, v := range []string{"passthrough:///a", "passthrough:///b"} {
+		cc, err := grpc.NewClient(v, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer cc.Close()
+		ccs = append(ccs, cc)
+	}
+	call := func(cc *grpc.ClientConn) int32 {
+		reply := &wrapperspb.Int32Value{}
+		require.NoError(t, i(context.Background(), "/test.Service/Get", wrapperspb.String("req"), reply, cc, inv))
+		return reply.Value
+	}
+	assert.Equal(t, int32(1), call(ccs[0]))
+	// the same request to another backend is not served from the first one cache
+	assert.Equal(t, int32(2), call(ccs[1]))
+	assert.Equal(t, int32(1), call(ccs[0]))
+	assert.Equal(t, int32(2), call(ccs[1]))
+}
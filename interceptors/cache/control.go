@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ControlKey is the metadata key used by the client to control the cache lookups
+// and by the server to send caching hints, using the HTTP Cache-Control directives:
+//
+//   - no-store: the response is neither served from nor stored in the cache
+//   - no-cache: the response is not served from the cache but can be stored
+//   - max-age=<seconds>: on requests, cached responses older than max-age are not served,
+//     on responses, the response can be cached for max-age
+const ControlKey = "cache-control"
+
+type control struct {
+	noStore bool
+	noCache bool
+	maxAge  *time.Duration
+}
+
+func parseControl(md metadata.MD) control {
+	var c control
+	for _, v := range md.Get(ControlKey) {
+		for _, d := range strings.Split(v, ",") {
+			d = strings.ToLower(strings.TrimSpace(d))
+			switch {
+			case d == "no-store", d == "private":
+				c.noStore = true
+			case d == "no-cache":
+				c.noCache = true
+			case strings.HasPrefix(d, "max-age="):
+				s, err := strconv.Atoi(strings.TrimPrefix(d, "max-age="))
+				if err != nil || s < 0 {
+					continue
+				}
+				age := time.Duration(s) * time.Second
+				c.maxAge = &age
+			}
+		}
+	}
+	return c
+}
+
+func maxAge(ttl time.Duration) string {
+	return fmt.Sprintf("max-age=%d", int(ttl.Seconds()))
+}
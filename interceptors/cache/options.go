@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"time"
+)
+
+const (
+	// DefaultMaxEntries is the maximum number of entries of the default store.
+	DefaultMaxEntries = 1024
+	// DefaultMaxBytes is the maximum size of the responses held by the default store.
+	DefaultMaxBytes = 32 << 20
+)
+
+var defaultOptions = options{
+	metadataKeys: []string{"authorization"},
+}
+
+type Option func(*options)
+
+// WithStore sets the store holding the cached responses.
+// It defaults to an in-memory LRU bounded by DefaultMaxEntries and DefaultMaxBytes.
+func WithStore(s Store) Option {
+	return func(o *options) {
+		o.store = s
+	}
+}
+
+// WithTTL enables the caching of the given methods responses for ttl.
+// It takes a list of fully qualified method names, e.g. /helloworld.Greeter/SayHello,
+// or service wildcards, e.g. /helloworld.Greeter/*.
+// It takes precedence over the (linka.cache) method option.
+func WithTTL(ttl time.Duration, methods ...string) Option {
+	return func(o *options) {
+		o.ttls = append(o.ttls, methodTTL{ttl: ttl, methods: methods})
+	}
+}
+
+// WithMetadataKeys sets the outgoing metadata keys that are part of the cache key,
+// so that calls with different values for these keys never share a response.
+// It defaults to the authorization key.
+func WithMetadataKeys(keys ...string) Option {
+	return func(o *options) {
+		o.metadataKeys = keys
+	}
+}
+
+type methodTTL struct {
+	ttl     time.Duration
+	methods []string
+}
+
+type options struct {
+	store        Store
+	ttls         []methodTTL
+	metadataKeys []string
+}
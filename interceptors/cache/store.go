@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	// Value is the marshaled response.
+	Value []byte
+	// Header is the response header metadata, returned to the callers on cache hits.
+	Header metadata.MD
+	// Created is the time at which the response was stored.
+	Created time.Time
+	// Expires is the time after which the response must not be served anymore.
+	Expires time.Time
+}
+
+func (e *Entry) expired(now time.Time) bool {
+	return !e.Expires.After(now)
+}
+
+// Store stores the cached responses.
+type Store interface {
+	Get(ctx context.Context, key string) (*Entry, bool)
+	Set(ctx context.Context, key string, e *Entry)
+	Delete(ctx context.Context, key string)
+}
+
+// NewLRU returns an in-memory Store evicting the least recently used entries once it holds
+// more than maxEntries entries or more than maxBytes bytes of responses.
+// A zero value disables the corresponding bound.
+func NewLRU(maxEntries int, maxBytes int64) Store {
+	return &lru{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+type lru struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type item struct {
+	key   string
+	entry *Entry
+}
+
+func (l *lru) Get(_ context.Context, key string) (*Entry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	i := e.Value.(*item)
+	if i.entry.expired(time.Now()) {
+		l.remove(e)
+		return nil, false
+	}
+	l.ll.MoveToFront(e)
+	return i.entry, true
+}
+
+func (l *lru) Set(_ context.Context, key string, entry *Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxBytes > 0 && int64(len(entry.Value)) > l.maxBytes {
+		return
+	}
+	if e, ok := l.items[key]; ok {
+		l.remove(e)
+	}
+	l.items[key] = l.ll.PushFront(&item{key: key, entry: entry})
+	l.size += int64(len(entry.Value))
+	for (l.maxEntries > 0 && l.ll.Len() > l.maxEntries) || (l.maxBytes > 0 && l.size > l.maxBytes) {
+		l.remove(l.ll.Back())
+	}
+}
+
+func (l *lru) Delete(_ context.Context, key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.items[key]; ok {
+		l.remove(e)
+	}
+}
+
+func (l *lru) remove(e *list.Element) {
+	i := l.ll.Remove(e).(*item)
+	delete(l.items, i.key)
+	l.size -= int64(len(i.entry.Value))
+}
@@ -0,0 +1,151 @@
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+)
+
+// NewInterceptors returns interceptors enforcing the methods deadline policies.
+//
+// The server interceptors apply the default timeout to the calls without deadline, shorten the deadlines
+// exceeding the max timeout, and reject with codes.DeadlineExceeded the calls with less than the min timeout left
+// before running the handler.
+// The client interceptors apply the client policies to the outgoing calls, see WithClientPolicy,
+// and shorten the deadline inherited from the call being served by the margin, see WithMargin.
+func NewInterceptors(opts ...Option) interceptors.Interceptors {
+	o := options{}
+	for _, v := range opts {
+		v(&o)
+	}
+	return &deadline{o: o}
+}
+
+type deadline struct {
+	o options
+}
+
+type budgetKey struct{}
+
+// Budget returns the time left to serve the current call, as enforced by the server interceptors.
+func Budget(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(budgetKey{}).(time.Time)
+	if !ok {
+		return 0, false
+	}
+	return time.Until(d), true
+}
+
+func (d *deadline) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel, err := d.apply(ctx, info.FullMethod, d.policy(info.FullMethod), 0)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+		if dl, ok := ctx.Deadline(); ok {
+			ctx = context.WithValue(ctx, budgetKey{}, dl)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (d *deadline) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel, err := d.apply(ss.Context(), info.FullMethod, d.policy(info.FullMethod), 0)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		if dl, ok := ctx.Deadline(); ok {
+			ctx = context.WithValue(ctx, budgetKey{}, dl)
+		}
+		return handler(srv, interceptors.NewContextServerStream(ctx, ss))
+	}
+}
+
+func (d *deadline) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel, err := d.apply(ctx, method, d.clientPolicy(method), d.margin(ctx))
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (d *deadline) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, cancel, err := d.apply(ctx, method, d.clientPolicy(method), d.margin(ctx))
+		if err != nil {
+			return nil, err
+		}
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		// the stream context is done when the stream finishes, whether it was consumed or not
+		context.AfterFunc(s.Context(), cancel)
+		return s, nil
+	}
+}
+
+// margin returns the margin to apply to the outgoing calls made while serving a call.
+func (d *deadline) margin(ctx context.Context) time.Duration {
+	if _, ok := ctx.Value(budgetKey{}).(time.Time); !ok {
+		return 0
+	}
+	return d.o.margin
+}
+
+// apply returns a context with the deadline bounded by the policy and shortened by the margin.
+func (d *deadline) apply(ctx context.Context, method string, p *DeadlinePolicy, margin time.Duration) (context.Context, context.CancelFunc, error) {
+	now := time.Now()
+	dl, ok := ctx.Deadline()
+	if ok {
+		dl = dl.Add(-margin)
+	}
+	if v := p.GetDefault(); !ok && v != nil {
+		dl, ok = now.Add(v.AsDuration()), true
+	}
+	if v := p.GetMax(); v != nil && (!ok || dl.Sub(now) > v.AsDuration()) {
+		dl, ok = now.Add(v.AsDuration()), true
+	}
+	if !ok {
+		return ctx, func() {}, nil
+	}
+	left := dl.Sub(now)
+	if left <= 0 || (p.GetMin() != nil && left < p.GetMin().AsDuration()) {
+		return nil, nil, errors.DeadlineExceededf("%s: not enough time left to handle the call: %v", method, left.Round(time.Millisecond))
+	}
+	ctx, cancel := context.WithDeadline(ctx, dl)
+	return ctx, cancel, nil
+}
+
+// policy returns the first policy given with the options matching the method, or the method option.
+func (d *deadline) policy(method string) *DeadlinePolicy {
+	for _, v := range d.o.policies {
+		if methods.MatchAny(v.methods, method) {
+			return v.policy
+		}
+	}
+	p, _ := methods.Option[*DeadlinePolicy](method, E_Deadline)
+	return p
+}
+
+// clientPolicy returns the first client policy given with the options matching the method.
+func (d *deadline) clientPolicy(method string) *DeadlinePolicy {
+	for _, v := range d.o.clientPolicies {
+		if methods.MatchAny(v.methods, method) {
+			return v.policy
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: interceptors/deadline/deadline.proto
+
+package deadline
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DeadlinePolicy describes the deadlines bounds of a method calls.
+type DeadlinePolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// default is the timeout applied to the calls without deadline.
+	Default *durationpb.Duration `protobuf:"bytes,1,opt,name=default,proto3" json:"default,omitempty"`
+	// max is the maximum timeout of the calls: longer deadlines are shortened.
+	Max *durationpb.Duration `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+	// min is the minimum remaining time of the calls: calls with less time left are rejected.
+	Min           *durationpb.Duration `protobuf:"bytes,3,opt,name=min,proto3" json:"min,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadlinePolicy) Reset() {
+	*x = DeadlinePolicy{}
+	mi := &file_interceptors_deadline_deadline_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadlinePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadlinePolicy) ProtoMessage() {}
+
+func (x *DeadlinePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_interceptors_deadline_deadline_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadlinePolicy.ProtoReflect.Descriptor instead.
+func (*DeadlinePolicy) Descriptor() ([]byte, []int) {
+	return file_interceptors_deadline_deadline_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeadlinePolicy) GetDefault() *durationpb.Duration {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+func (x *DeadlinePolicy) GetMax() *durationpb.Duration {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *DeadlinePolicy) GetMin() *durationpb.Duration {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+var file_interceptors_deadline_deadline_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*DeadlinePolicy)(nil),
+		Field:         51005,
+		Name:          "linka.deadline",
+		Tag:           "bytes,51005,opt,name=deadline",
+		Filename:      "interceptors/deadline/deadline.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// deadline is the policy enforced by the deadline interceptors.
+	//
+	// optional linka.DeadlinePolicy deadline = 51005;
+	E_Deadline = &file_interceptors_deadline_deadline_proto_extTypes[0]
+)
+
+var File_interceptors_deadline_deadline_proto protoreflect.FileDescriptor
+
+const file_interceptors_deadline_deadline_proto_rawDesc = "" +
+	"\n" +
+	"$interceptors/deadline/deadline.proto\x12\x05linka\x1a google/protobuf/descriptor.proto\x1a\x1egoogle/protobuf/duration.proto\"\x9f\x01\n" +
+	"\x0eDeadlinePolicy\x123\n" +
+	"\adefault\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\adefault\x12+\n" +
+	"\x03max\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03max\x12+\n" +
+	"\x03min\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03min:S\n" +
+	"\bdeadline\x12\x1e.google.protobuf.MethodOptions\x18\xbd\x8e\x03 \x01(\v2\x15.linka.DeadlinePolicyR\bdeadlineB3Z1go.linka.cloud/grpc-toolkit/interceptors/deadlineb\x06proto3"
+
+var (
+	file_interceptors_deadline_deadline_proto_rawDescOnce sync.Once
+	file_interceptors_deadline_deadline_proto_rawDescData []byte
+)
+
+func file_interceptors_deadline_deadline_proto_rawDescGZIP() []byte {
+	file_interceptors_deadline_deadline_proto_rawDescOnce.Do(func() {
+		file_interceptors_deadline_deadline_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_interceptors_deadline_deadline_proto_rawDesc), len(file_interceptors_deadline_deadline_proto_rawDesc)))
+	})
+	return file_interceptors_deadline_deadline_proto_rawDescData
+}
+
+var file_interceptors_deadline_deadline_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_interceptors_deadline_deadline_proto_goTypes = []any{
+	(*DeadlinePolicy)(nil),             // 0: linka.DeadlinePolicy
+	(*durationpb.Duration)(nil),        // 1: google.protobuf.Duration
+	(*descriptorpb.MethodOptions)(nil), // 2: google.protobuf.MethodOptions
+}
+var file_interceptors_deadline_deadline_proto_depIdxs = []int32{
+	1, // 0: linka.DeadlinePolicy.default:type_name -> google.protobuf.Duration
+	1, // 1: linka.DeadlinePolicy.max:type_name -> google.protobuf.Duration
+	1, // 2: linka.DeadlinePolicy.min:type_name -> google.protobuf.Duration
+	2, // 3: linka.deadline:extendee -> google.protobuf.MethodOptions
+	0, // 4: linka.deadline:type_name -> linka.DeadlinePolicy
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	4, // [4:5] is the sub-list for extension type_name
+	3, // [3:4] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_interceptors_deadline_deadline_proto_init() }
+func file_interceptors_deadline_deadline_proto_init() {
+	if File_interceptors_deadline_deadline_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interceptors_deadline_deadline_proto_rawDesc), len(file_interceptors_deadline_deadline_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_interceptors_deadline_deadline_proto_goTypes,
+		DependencyIndexes: file_interceptors_deadline_deadline_proto_depIdxs,
+		MessageInfos:      file_interceptors_deadline_deadline_proto_msgTypes,
+		ExtensionInfos:    file_interceptors_deadline_deadline_proto_extTypes,
+	}.Build()
+	File_interceptors_deadline_deadline_proto = out.File
+	file_interceptors_deadline_deadline_proto_goTypes = nil
+	file_interceptors_deadline_deadline_proto_depIdxs = nil
+}
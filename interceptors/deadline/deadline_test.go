@@ -0,0 +1,158 @@
+package deadline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func policy(def, max, min time.Duration) *DeadlinePolicy {
+	p := &DeadlinePolicy{}
+	if def != 0 {
+		p.Default = durationpb.New(def)
+	}
+	if max != 0 {
+		p.Max = durationpb.New(max)
+	}
+	if min != 0 {
+		p.Min = durationpb.New(min)
+	}
+	return p
+}
+
+// serve returns the time left to the handler.
+func serve(t *testing.T, i grpc.UnaryServerInterceptor, ctx context.Context, method string) (time.Duration, error) {
+	var left time.Duration
+	_, err := i(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		dl, ok := ctx.Deadline()
+		require.True(t, ok)
+		left = time.Until(dl)
+		b, ok := Budget(ctx)
+		require.True(t, ok)
+		assert.InDelta(t, left, b, float64(10*time.Millisecond))
+		return nil, nil
+	})
+	return left, err
+}
+
+func TestServer(t *testing.T) {
+	i := NewInterceptors(
+		WithPolicy(policy(time.Second, 0, 0), "/svc/Default"),
+		WithPolicy(policy(time.Second, 2*time.Second, 100*time.Millisecond)),
+	).UnaryServerInterceptor()
+	ctx := context.Background()
+
+	left, err := serve(t, i, ctx, "/svc/Default")
+	require.NoError(t, err)
+	assert.InDelta(t, time.Second, left, float64(50*time.Millisecond))
+
+	tctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	left, err = serve(t, i, tctx, "/svc/Default")
+	require.NoError(t, err)
+	assert.InDelta(t, time.Minute, left, float64(50*time.Millisecond))
+
+	left, err = serve(t, i, tctx, "/svc/Max")
+	require.NoError(t, err)
+	assert.InDelta(t, 2*time.Second, left, float64(50*time.Millisecond))
+
+	sctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = serve(t, i, sctx, "/svc/Max")
+	assert.True(t, errors.IsDeadlineExceeded(err))
+}
+
+func TestClient(t *testing.T) {
+	in := NewInterceptors(WithMargin(time.Second)).(*deadline)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	call := func(ctx context.Context) (time.Duration, bool, error) {
+		var (
+			left time.Duration
+			ok   bool
+		)
+		err := in.UnaryClientInterceptor()(ctx, "/svc/Out", nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			var dl time.Time
+			dl, ok = ctx.Deadline()
+			left = time.Until(dl)
+			return nil
+		})
+		return left, ok, err
+	}
+	// no margin outside of a served call
+	left, _, err := call(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 10*time.Second, left, float64(50*time.Millisecond))
+
+	_, err = in.UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/In"}, func(ctx context.Context, req any) (any, error) {
+		left, ok, err := call(ctx)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.InDelta(t, 9*time.Second, left, float64(50*time.Millisecond))
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	_, ok, err := call(context.Background())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestClientPolicy(t *testing.T) {
+	in := NewInterceptors(
+		WithPolicy(policy(0, 0, time.Minute)),
+		WithClientPolicy(policy(time.Second, 0, 0), "/svc/Out"),
+	).UnaryClientInterceptor()
+	call := func(method string) (time.Duration, bool, error) {
+		var (
+			left time.Duration
+			ok   bool
+		)
+		err := in(context.Background(), method, nil, nil, nil, func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			var dl time.Time
+			dl, ok = ctx.Deadline()
+			left = time.Until(dl)
+			return nil
+		})
+		return left, ok, err
+	}
+	// the server min timeout does not apply to the outgoing calls
+	left, ok, err := call("/svc/Out")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.InDelta(t, time.Second, left, float64(50*time.Millisecond))
+	_, ok, err = call("/svc/Other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestClientStreamRelease(t *testing.T) {
+	in := NewInterceptors(WithClientPolicy(policy(time.Minute, 0, 0))).StreamClientInterceptor()
+	sctx, finish := context.WithCancel(context.Background())
+	var ctx context.Context
+	_, err := in(context.Background(), &grpc.StreamDesc{}, nil, "/svc/Out", func(c context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = c
+		return &clientStream{ctx: sctx}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, ctx.Err())
+	// the stream is never consumed, its deadline context is released when it finishes
+	finish()
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,52 @@
+package deadline
+
+import (
+	"time"
+)
+
+type Option func(*options)
+
+// WithPolicy sets the deadline policy of the given methods, or of all methods if none is given.
+// It takes a list of fully qualified method names, e.g. /helloworld.Greeter/SayHello,
+// or service wildcards, e.g. /helloworld.Greeter/*.
+// The first matching policy takes precedence over the (linka.deadline) method option.
+func WithPolicy(p *DeadlinePolicy, methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		o.policies = append(o.policies, methodPolicy{policy: p, methods: methods})
+	}
+}
+
+// WithClientPolicy sets the deadline policy of the outgoing calls to the given methods, or to all methods if none is given.
+// The server policies and the (linka.deadline) method option do not apply to the outgoing calls.
+// See WithPolicy.
+func WithClientPolicy(p *DeadlinePolicy, methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		o.clientPolicies = append(o.clientPolicies, methodPolicy{policy: p, methods: methods})
+	}
+}
+
+// WithMargin sets the time reserved by the client interceptors for the server to handle
+// the outgoing calls responses: the outgoing calls made while serving a call
+// get the incoming call deadline shortened by the margin.
+func WithMargin(d time.Duration) Option {
+	return func(o *options) {
+		o.margin = d
+	}
+}
+
+type methodPolicy struct {
+	policy  *DeadlinePolicy
+	methods []string
+}
+
+type options struct {
+	policies       []methodPolicy
+	clientPolicies []methodPolicy
+	margin         time.Duration
+}
@@ -0,0 +1,87 @@
+package dedup
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/callkey"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+)
+
+// NewInterceptors returns client interceptors collapsing identical concurrent unary calls
+// into a single upstream call, whose response is shared with all the callers.
+//
+// Calls are identical if they have the same method, the same deterministically marshaled request
+// and the same values for the selected metadata keys.
+// Only the methods given with WithMethods or annotated with the (linka.dedup) option are deduplicated.
+// The call options of the callers waiting for the shared call, e.g. grpc.Header, are not applied.
+func NewInterceptors(opts ...Option) interceptors.ClientInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	return &dedup{o: o}
+}
+
+type dedup struct {
+	o options
+	g singleflight.Group
+}
+
+func (d *dedup) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !d.enabled(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		r, ok := reply.(proto.Message)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		key, ok := callkey.Key(ctx, callkey.Target(cc), method, req, d.o.metadataKeys...)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ch := d.g.DoChan(key, func() (any, error) {
+			res := r.ProtoReflect().New().Interface()
+			if err := invoker(ctx, method, req, res, cc, opts...); err != nil {
+				return nil, err
+			}
+			return res, nil
+		})
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case res := <-ch:
+			if res.Err != nil {
+				// the shared call may have been canceled by the caller that issued it
+				if res.Shared && ctx.Err() == nil && (errors.IsCanceled(res.Err) || errors.IsDeadlineExceeded(res.Err)) {
+					return invoker(ctx, method, req, reply, cc, opts...)
+				}
+				return res.Err
+			}
+			proto.Reset(r)
+			proto.Merge(r, res.Val.(proto.Message))
+			return nil
+		}
+	}
+}
+
+func (d *dedup) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+func (d *dedup) enabled(method string) bool {
+	if methods.MatchAny(d.o.methods, method) {
+		return true
+	}
+	v, _ := methods.Option[bool](method, E_Dedup)
+	return v
+}
@@ -0,0 +1,85 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: interceptors/dedup/dedup.proto
+
+package dedup
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var file_interceptors_dedup_dedup_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         51001,
+		Name:          "linka.dedup",
+		Tag:           "varint,51001,opt,name=dedup",
+		Filename:      "interceptors/dedup/dedup.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// dedup enables the collapsing of identical concurrent calls to the method
+	// by the dedup client interceptors.
+	//
+	// optional bool dedup = 51001;
+	E_Dedup = &file_interceptors_dedup_dedup_proto_extTypes[0]
+)
+
+var File_interceptors_dedup_dedup_proto protoreflect.FileDescriptor
+
+const file_interceptors_dedup_dedup_proto_rawDesc = "" +
+	"\n" +
+	"\x1einterceptors/dedup/dedup.proto\x12\x05linka\x1a google/protobuf/descriptor.proto:6\n" +
+	"\x05dedup\x12\x1e.google.protobuf.MethodOptions\x18\xb9\x8e\x03 \x01(\bR\x05dedupB0Z.go.linka.cloud/grpc-toolkit/interceptors/dedupb\x06proto3"
+
+var file_interceptors_dedup_dedup_proto_goTypes = []any{
+	(*descriptorpb.MethodOptions)(nil), // 0: google.protobuf.MethodOptions
+}
+var file_interceptors_dedup_dedup_proto_depIdxs = []int32{
+	0, // 0: linka.dedup:extendee -> google.protobuf.MethodOptions
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_interceptors_dedup_dedup_proto_init() }
+func file_interceptors_dedup_dedup_proto_init() {
+	if File_interceptors_dedup_dedup_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_interceptors_dedup_dedup_proto_rawDesc), len(file_interceptors_dedup_dedup_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_interceptors_dedup_dedup_proto_goTypes,
+		DependencyIndexes: file_interceptors_dedup_dedup_proto_depIdxs,
+		ExtensionInfos:    file_interceptors_dedup_dedup_proto_extTypes,
+	}.Build()
+	File_interceptors_dedup_dedup_proto = out.File
+	file_interceptors_dedup_dedup_proto_goTypes = nil
+	file_interceptors_dedup_dedup_proto_depIdxs = nil
+}
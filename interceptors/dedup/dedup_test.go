@@ -0,0 +1,86 @@
+package dedup
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const method = "/test.Service/Get"
+
+func invoker(calls *atomic.Int32, release <-chan struct{}) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls.Add(1)
+		<-release
+		reply.(*wrapperspb.StringValue).Value = "reply: " + req.(*wrapperspb.StringValue).Value
+		return nil
+	}
+}
+
+func run(t *testing.T, i grpc.UnaryClientInterceptor, ctxs []context.Context, reqs []string, ccs ...*grpc.ClientConn) int32 {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	for n := range reqs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var cc *grpc.ClientConn
+			if len(ccs) != 0 {
+				cc = ccs[n]
+			}
+			reply := &wrapperspb.StringValue{}
+			require.NoError(t, i(ctxs[n], method, wrapperspb.String(reqs[n]), reply, cc, invoker(&calls, release)))
+			assert.Equal(t, "reply: "+reqs[n], reply.Value)
+		}()
+	}
+	// let the calls reach the invoker before releasing them
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	return calls.Load()
+}
+
+func TestDedup(t *testing.T) {
+	ctx := context.Background()
+	i := NewInterceptors(WithMethods("/test.Service/*")).UnaryClientInterceptor()
+
+	assert.Equal(t, int32(1), run(t, i, []context.Context{ctx, ctx, ctx}, []string{"a", "a", "a"}))
+	assert.Equal(t, int32(2), run(t, i, []context.Context{ctx, ctx, ctx}, []string{"a", "b", "a"}))
+
+	ctxs := []context.Context{
+		metadata.AppendToOutgoingContext(ctx, "authorization", "bearer a"),
+		metadata.AppendToOutgoingContext(ctx, "authorization", "bearer b"),
+		metadata.AppendToOutgoingContext(ctx, "authorization", "bearer a"),
+	}
+	assert.Equal(t, int32(2), run(t, i, ctxs, []string{"a", "a", "a"}))
+}
+
+func TestDedupTargets(t *testing.T) {
+	ctx := context.Background()
+	i := NewInterceptors(WithMethods("/test.Service/*")).UnaryClientInterceptor()
+	var ccs []*grpc.ClientConn
+	for _, v := range []string{"passthrough:///a", "passthrough:///b", "passthrough:///a"} {
+		cc, err := grpc.NewClient(v, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer cc.Close()
+		ccs = append(ccs, cc)
+	}
+	// the calls to different backends are not merged
+	assert.Equal(t, int32(2), run(t, i, []context.Context{ctx, ctx, ctx}, []string{"a", "a", "a"}, ccs...))
+}
+
+func TestDedupDisabled(t *testing.T) {
+	ctx := context.Background()
+	i := NewInterceptors(WithMethods("/test.Service/List")).UnaryClientInterceptor()
+	assert.Equal(t, int32(3), run(t, i, []context.Context{ctx, ctx, ctx}, []string{"a", "a", "a"}))
+}
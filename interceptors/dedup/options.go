@@ -0,0 +1,30 @@
+package dedup
+
+var defaultOptions = options{
+	metadataKeys: []string{"authorization"},
+}
+
+type Option func(*options)
+
+// WithMethods enables the deduplication for the given methods, in addition to the ones
+// annotated with the (linka.dedup) option. It takes a list of fully qualified method names,
+// e.g. /helloworld.Greeter/SayHello, or service wildcards, e.g. /helloworld.Greeter/*.
+func WithMethods(methods ...string) Option {
+	return func(o *options) {
+		o.methods = append(o.methods, methods...)
+	}
+}
+
+// WithMetadataKeys sets the outgoing metadata keys that are part of the calls key,
+// so that calls with different values for these keys are never collapsed.
+// It defaults to the authorization key.
+func WithMetadataKeys(keys ...string) Option {
+	return func(o *options) {
+		o.metadataKeys = keys
+	}
+}
+
+type options struct {
+	methods      []string
+	metadataKeys []string
+}
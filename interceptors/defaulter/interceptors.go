@@ -4,65 +4,79 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"go.linka.cloud/grpc-toolkit/interceptors"
 )
 
-type interceptor struct{}
+type interceptor struct {
+	o options
+}
 
-func NewInterceptors() interceptors.Interceptors {
-	return &interceptor{}
+func NewInterceptors(opts ...Option) interceptors.Interceptors {
+	i := &interceptor{}
+	for _, o := range opts {
+		o(&i.o)
+	}
+	return i
 }
 
-func defaults(v interface{}) {
+func (i interceptor) defaults(v interface{}) {
 	if d, ok := v.(interface{ Default() }); v != nil && ok {
 		d.Default()
 	}
+	if m, ok := v.(proto.Message); ok && m != nil {
+		for _, fn := range i.o.fns {
+			fn(m)
+		}
+	}
 }
 
 func (i interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		defaults(req)
+		i.defaults(req)
 		return handler(ctx, req)
 	}
 }
 
 func (i interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		defaults(req)
+		i.defaults(req)
 		return invoker(ctx, method, req, reply, cc, opts...)
 	}
 }
 
 func (i interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		wrapper := &recvWrapper{ServerStream: stream}
+		wrapper := &recvWrapper{ServerStream: stream, defaults: i.defaults}
 		return handler(srv, wrapper)
 	}
 }
 
 func (i interceptor) StreamClientInterceptor() grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		desc.Handler = (&sendWrapper{handler: desc.Handler}).Handler()
+		desc.Handler = (&sendWrapper{handler: desc.Handler, defaults: i.defaults}).Handler()
 		return streamer(ctx, desc, cc, method)
 	}
 }
 
 type recvWrapper struct {
 	grpc.ServerStream
+	defaults func(v interface{})
 }
 
 func (s *recvWrapper) RecvMsg(m interface{}) error {
 	if err := s.ServerStream.RecvMsg(m); err != nil {
 		return err
 	}
-	defaults(m)
+	s.defaults(m)
 	return nil
 }
 
 type sendWrapper struct {
 	grpc.ServerStream
-	handler grpc.StreamHandler
+	handler  grpc.StreamHandler
+	defaults func(v interface{})
 }
 
 func (s *sendWrapper) Handler() grpc.StreamHandler {
@@ -72,6 +86,6 @@ func (s *sendWrapper) Handler() grpc.StreamHandler {
 }
 
 func (s *sendWrapper) SendMsg(m interface{}) error {
-	defaults(m)
+	s.defaults(m)
 	return s.ServerStream.SendMsg(m)
 }
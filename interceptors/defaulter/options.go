@@ -0,0 +1,19 @@
+package defaulter
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+type Option func(*options)
+
+// WithDefaults adds functions setting the messages defaults, run after their Default() method,
+// e.g. pagination.Defaults.
+func WithDefaults(fns ...func(m proto.Message)) Option {
+	return func(o *options) {
+		o.fns = append(o.fns, fns...)
+	}
+}
+
+type options struct {
+	fns []func(m proto.Message)
+}
@@ -0,0 +1,38 @@
+package errmap
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+)
+
+// NewServerInterceptors returns server interceptors converting the handlers errors that are not status errors
+// to status errors with errors.FromError, so that e.g. a wrapped os.ErrNotExist becomes codes.NotFound
+// instead of codes.Unknown. The converted errors wrap the handlers errors.
+func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
+	m := &mapper{}
+	for _, o := range opts {
+		o(&m.o)
+	}
+	return m
+}
+
+type mapper struct {
+	o options
+}
+
+func (m *mapper) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		return res, errors.FromError(err, m.o.mappers...)
+	}
+}
+
+func (m *mapper) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return errors.FromError(handler(srv, ss), m.o.mappers...)
+	}
+}
@@ -0,0 +1,39 @@
+package errmap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+var errConflict = fmt.Errorf("conflict")
+
+func TestInterceptors(t *testing.T) {
+	i := NewServerInterceptors(WithMappers(errors.MapIs(errConflict, codes.Aborted))).UnaryServerInterceptor()
+	call := func(err error) error {
+		_, err = i(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+			return nil, err
+		})
+		return err
+	}
+	assert.NoError(t, call(nil))
+
+	notFound := fmt.Errorf("open config: %w", os.ErrNotExist)
+	err := call(notFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Equal(t, notFound.Error(), status.Convert(err).Message())
+
+	assert.Equal(t, codes.Aborted, status.Code(call(fmt.Errorf("update: %w", errConflict))))
+	assert.Equal(t, codes.Canceled, status.Code(call(context.Canceled)))
+	assert.Equal(t, codes.Unknown, status.Code(call(fmt.Errorf("boom"))))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(call(errors.FailedPreconditionf("precondition"))))
+}
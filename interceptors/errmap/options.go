@@ -0,0 +1,18 @@
+package errmap
+
+import (
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+type Option func(*options)
+
+// WithMappers adds mappers tried before the ones registered with errors.RegisterMapper.
+func WithMappers(mappers ...errors.Mapper) Option {
+	return func(o *options) {
+		o.mappers = append(o.mappers, mappers...)
+	}
+}
+
+type options struct {
+	mappers []errors.Mapper
+}
@@ -0,0 +1,184 @@
+package fieldmask
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+// Wildcard is the field mask path selecting all the fields.
+const Wildcard = "*"
+
+// tree is a tree of field names, the leaves are the selected fields.
+type tree map[protoreflect.Name]tree
+
+// newTree validates the paths against the message descriptor and returns their tree.
+// The paths go through the repeated and map message fields, applying to all their elements.
+func newTree(md protoreflect.MessageDescriptor, paths []string) (tree, error) {
+	t := tree{}
+	for _, p := range paths {
+		n, d := t, md
+		parts := strings.Split(p, ".")
+		for i, v := range parts {
+			if d == nil {
+				return nil, errors.InvalidArgumentf("invalid field mask path %q: %s is not a message", p, strings.Join(parts[:i], "."))
+			}
+			fd := d.Fields().ByName(protoreflect.Name(v))
+			if fd == nil {
+				return nil, errors.InvalidArgumentf("invalid field mask path %q: %s has no field %s", p, d.FullName(), v)
+			}
+			if fd.IsMap() {
+				fd = fd.MapValue()
+			}
+			d = fd.Message()
+			name := protoreflect.Name(v)
+			if i == len(parts)-1 {
+				n[name] = nil
+				break
+			}
+			c, ok := n[name]
+			if ok && c == nil {
+				// the parent is already selected
+				break
+			}
+			if !ok {
+				c = tree{}
+				n[name] = c
+			}
+			n = c
+		}
+	}
+	return t, nil
+}
+
+// Prune clears the fields of the message which are not selected by the field mask paths,
+// e.g. name and options.go_package. The paths go through the repeated and map message fields,
+// applying to all their elements. Nothing is cleared if the paths are empty or contain the Wildcard.
+func Prune(m proto.Message, paths ...string) error {
+	if m == nil || len(paths) == 0 || hasWildcard(paths) {
+		return nil
+	}
+	t, err := newTree(m.ProtoReflect().Descriptor(), paths)
+	if err != nil {
+		return err
+	}
+	prune(m.ProtoReflect(), t)
+	return nil
+}
+
+func prune(m protoreflect.Message, t tree) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		c, ok := t[fd.Name()]
+		switch {
+		case !ok:
+			m.Clear(fd)
+		case c == nil:
+		case fd.IsMap():
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				prune(v.Message(), c)
+				return true
+			})
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				prune(v.List().Get(i).Message(), c)
+			}
+		default:
+			prune(v.Message(), c)
+		}
+		return true
+	})
+}
+
+// Apply applies the update of the fields selected by the mask from src to dst, e.g. a stored message,
+// following the google.aip.dev/134 semantics:
+// the selected fields set in src replace the dst ones, the ones unset in src are cleared from dst,
+// the repeated and map fields are replaced as a whole.
+// An empty mask selects the fields set in src, and the Wildcard replaces dst with src.
+// The intermediate fields of the paths must be singular messages.
+func Apply(dst, src proto.Message, mask *fieldmaskpb.FieldMask) error {
+	d, s := dst.ProtoReflect(), src.ProtoReflect()
+	if d.Descriptor().FullName() != s.Descriptor().FullName() {
+		return errors.InvalidArgumentf("cannot apply %s to %s", s.Descriptor().FullName(), d.Descriptor().FullName())
+	}
+	paths := mask.GetPaths()
+	if hasWildcard(paths) {
+		proto.Reset(dst)
+		proto.Merge(dst, src)
+		return nil
+	}
+	if len(paths) == 0 {
+		s.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			paths = append(paths, string(fd.Name()))
+			return true
+		})
+	}
+	for _, p := range paths {
+		if err := apply(d, s, p, strings.Split(p, ".")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func apply(dst, src protoreflect.Message, path string, parts []string) error {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(parts[0]))
+	if fd == nil {
+		return errors.InvalidArgumentf("invalid field mask path %q: %s has no field %s", path, dst.Descriptor().FullName(), parts[0])
+	}
+	if len(parts) == 1 {
+		dst.Clear(fd)
+		if src.Has(fd) {
+			copyField(dst, fd, src.Get(fd))
+		}
+		return nil
+	}
+	if fd.IsList() || fd.IsMap() || fd.Message() == nil {
+		return errors.InvalidArgumentf("invalid field mask path %q: %s is not a singular message", path, fd.Name())
+	}
+	if !src.Has(fd) && !dst.Has(fd) {
+		// validate the rest of the path against the descriptor
+		_, err := newTree(fd.Message(), []string{strings.Join(parts[1:], ".")})
+		return err
+	}
+	return apply(dst.Mutable(fd).Message(), src.Get(fd).Message(), path, parts[1:])
+}
+
+// copyField sets a deep copy of the value to the cleared field.
+func copyField(dst protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	clone := func(v protoreflect.Value, md protoreflect.MessageDescriptor) protoreflect.Value {
+		if md == nil {
+			return v
+		}
+		return protoreflect.ValueOfMessage(proto.Clone(v.Message().Interface()).ProtoReflect())
+	}
+	switch {
+	case fd.IsList():
+		l := dst.Mutable(fd).List()
+		for i := 0; i < v.List().Len(); i++ {
+			l.Append(clone(v.List().Get(i), fd.Message()))
+		}
+	case fd.IsMap():
+		m := dst.Mutable(fd).Map()
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			m.Set(k, clone(v, fd.MapValue().Message()))
+			return true
+		})
+	case fd.Kind() == protoreflect.BytesKind:
+		dst.Set(fd, protoreflect.ValueOfBytes(append([]byte(nil), v.Bytes()...)))
+	default:
+		dst.Set(fd, clone(v, fd.Message()))
+	}
+}
+
+func hasWildcard(paths []string) bool {
+	for _, v := range paths {
+		if v == Wildcard {
+			return true
+		}
+	}
+	return false
+}
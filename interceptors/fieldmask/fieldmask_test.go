@@ -0,0 +1,157 @@
+package fieldmask
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+const method = "/fieldmask.test.Service/Get"
+
+var request protoreflect.MessageDescriptor
+
+func init() {
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("interceptors/fieldmask/fieldmask_test.proto"),
+		Package:    proto.String("fieldmask.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/field_mask.proto", "google/protobuf/descriptor.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Request"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("read_mask"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".google.protobuf.FieldMask"),
+				JsonName: proto.String("readMask"),
+			}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Service"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Get"),
+				InputType:  proto.String(".fieldmask.test.Request"),
+				OutputType: proto.String(".google.protobuf.FileDescriptorProto"),
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+	request = fd.Messages().ByName("Request")
+}
+
+func file() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("file.proto"),
+		Package: proto.String("pkg"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("A"), Field: []*descriptorpb.FieldDescriptorProto{{Name: proto.String("f"), Number: proto.Int32(1)}}},
+			{Name: proto.String("B")},
+		},
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("go/pkg"), JavaPackage: proto.String("java.pkg")},
+	}
+}
+
+func TestPrune(t *testing.T) {
+	f := file()
+	require.NoError(t, Prune(f, "name", "message_type.name", "options.go_package"))
+	assert.True(t, proto.Equal(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("file.proto"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("A")}, {Name: proto.String("B")}},
+		Options:     &descriptorpb.FileOptions{GoPackage: proto.String("go/pkg")},
+	}, f), f.String())
+
+	f = file()
+	require.NoError(t, Prune(f, Wildcard))
+	assert.True(t, proto.Equal(file(), f))
+
+	assert.True(t, errors.IsInvalidArgument(Prune(f, "name.value")))
+	assert.True(t, errors.IsInvalidArgument(Prune(f, "unknown")))
+}
+
+func TestApply(t *testing.T) {
+	src := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String("new.proto"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("C")}},
+	}
+
+	dst := file()
+	require.NoError(t, Apply(dst, src, &fieldmaskpb.FieldMask{Paths: []string{"name", "options.go_package", "message_type"}}))
+	want := file()
+	want.Name = proto.String("new.proto")
+	want.Options.GoPackage = nil
+	want.MessageType = []*descriptorpb.DescriptorProto{{Name: proto.String("C")}}
+	assert.True(t, proto.Equal(want, dst), dst.String())
+	src.MessageType[0].Name = proto.String("D")
+	assert.Equal(t, "C", dst.MessageType[0].GetName())
+
+	dst = file()
+	require.NoError(t, Apply(dst, src, nil))
+	assert.Equal(t, "new.proto", dst.GetName())
+	assert.Equal(t, "pkg", dst.GetPackage())
+	assert.Len(t, dst.MessageType, 1)
+
+	dst = file()
+	require.NoError(t, Apply(dst, src, &fieldmaskpb.FieldMask{Paths: []string{Wildcard}}))
+	assert.True(t, proto.Equal(src, dst))
+
+	assert.True(t, errors.IsInvalidArgument(Apply(file(), src, &fieldmaskpb.FieldMask{Paths: []string{"message_type.name"}})))
+	assert.True(t, errors.IsInvalidArgument(Apply(file(), src, &fieldmaskpb.FieldMask{Paths: []string{"source_code_info.unknown"}})))
+}
+
+func TestInterceptor(t *testing.T) {
+	i := NewServerInterceptors().UnaryServerInterceptor()
+	stored := file()
+	call := func(ctx context.Context, req proto.Message) (*descriptorpb.FileDescriptorProto, error) {
+		res, err := i(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return stored, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.(*descriptorpb.FileDescriptorProto), nil
+	}
+
+	req := dynamicpb.NewMessage(request)
+	res, err := call(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(file(), res))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "name, options.java_package"))
+	res, err = call(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "file.proto", res.GetName())
+	assert.Empty(t, res.GetPackage())
+	assert.Equal(t, "java.pkg", res.GetOptions().GetJavaPackage())
+	assert.True(t, proto.Equal(file(), stored), "the handler response should not be modified")
+
+	req.Set(request.Fields().ByName("read_mask"), protoreflect.ValueOfMessage((&fieldmaskpb.FieldMask{Paths: []string{"package"}}).ProtoReflect()))
+	res, err = call(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, res.GetName())
+	assert.Equal(t, "pkg", res.GetPackage())
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "unknown"))
+	_, err = i(ctx, dynamicpb.NewMessage(request), &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		require.Fail(t, "the handler should not run")
+		return nil, nil
+	})
+	assert.True(t, errors.IsInvalidArgument(err))
+}
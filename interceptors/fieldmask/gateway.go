@@ -0,0 +1,125 @@
+package fieldmask
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// GatewayOptions returns the gateway options marshaling the unary responses pruned by the server interceptors
+// without their unpopulated fields, which the gateway default marshaler emits as zero values.
+// The other responses are marshaled like with the gateway default marshaler.
+// The options must be given after the other forward response options, and the gateway handler
+// must be wrapped by GatewayMiddleware, e.g. with service.WithMiddlewares.
+func GatewayOptions() []runtime.ServeMuxOption {
+	m := &gatewayMarshaler{
+		Marshaler: newJSONMarshaler(true),
+		pruned:    newJSONMarshaler(false),
+	}
+	return []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, m),
+		runtime.WithForwardResponseOption(m.forward),
+	}
+}
+
+// GatewayMiddleware wraps the response writer of each request so that the responses pruned
+// by the server interceptors can be marshaled without their unpopulated fields.
+func GatewayMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&responseWriter{ResponseWriter: w}, r)
+	})
+}
+
+func newJSONMarshaler(emitUnpopulated bool) runtime.Marshaler {
+	return &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: emitUnpopulated},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+}
+
+type gatewayMarshaler struct {
+	runtime.Marshaler
+	pruned runtime.Marshaler
+}
+
+// forward marks the response as pruned if the server interceptors returned the applied mask.
+func (g *gatewayMarshaler) forward(ctx context.Context, w http.ResponseWriter, m proto.Message) error {
+	rw, ok := w.(*responseWriter)
+	if !ok {
+		return nil
+	}
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok || len(md.HeaderMD.Get(MetadataKey)) == 0 {
+		return nil
+	}
+	// the response was marshaled by another marshaler
+	if w.Header().Get("Content-Type") != g.ContentType(m) {
+		return nil
+	}
+	var v any = m
+	if rb, ok := m.(interface{ XXX_ResponseBody() any }); ok {
+		v = rb.XXX_ResponseBody()
+	}
+	rw.body = v
+	rw.marshaler = g.pruned
+	return nil
+}
+
+// responseWriter replaces the body written by the gateway with the pruned response marshaling.
+type responseWriter struct {
+	http.ResponseWriter
+	body      any
+	marshaler runtime.Marshaler
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	// the gateway failed to forward the response
+	if code >= http.StatusMultipleChoices {
+		w.body = nil
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.body == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	v := w.body
+	w.body = nil
+	buf, err := w.marshaler.Marshal(v)
+	if err != nil {
+		logger.StandardLogger().Debugf("failed to marshal pruned response: %v", err)
+		return w.ResponseWriter.Write(b)
+	}
+	if w.Header().Get("Content-Length") != "" {
+		w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
+	}
+	if _, err := w.ResponseWriter.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,122 @@
+package fieldmask
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestGateway(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.UnaryInterceptor(NewServerInterceptors().UnaryServerInterceptor()))
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "fieldmask.test.Service",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Get",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				req := dynamicpb.NewMessage(request)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+					return file(), nil
+				})
+			},
+		}},
+	}, struct{}{})
+	go s.Serve(lis)
+	defer s.Stop()
+	cc, err := grpc.NewClient("passthrough:///bufconn", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer cc.Close()
+
+	get := func(t *testing.T, mux *runtime.ServeMux, mask string, body bool, code int) map[string]any {
+		require.NoError(t, mux.HandlePath(http.MethodGet, "/file", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			_, outbound := runtime.MarshalerForRequest(mux, r)
+			ctx, err := runtime.AnnotateContext(r.Context(), mux, r, method)
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, r, err)
+				return
+			}
+			var md runtime.ServerMetadata
+			res := &descriptorpb.FileDescriptorProto{}
+			if err := cc.Invoke(ctx, method, dynamicpb.NewMessage(request), res, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD)); err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, r, err)
+				return
+			}
+			var m proto.Message = res
+			if body {
+				m = &responseBody{res}
+			}
+			runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(ctx, md), mux, outbound, w, r, m, mux.GetForwardResponseOptions()...)
+		}))
+		r := httptest.NewRequest(http.MethodGet, "/file", nil)
+		if mask != "" {
+			r.Header.Set("X-Field-Mask", mask)
+		}
+		w := httptest.NewRecorder()
+		GatewayMiddleware(mux).ServeHTTP(w, r)
+		require.Equal(t, code, w.Code, w.Body.String())
+		if code == http.StatusOK {
+			assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+		}
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+		return out
+	}
+	headers := runtime.WithIncomingHeaderMatcher(func(s string) (string, bool) {
+		return s, true
+	})
+
+	// the default marshaler emits the pruned fields as zero values
+	res := get(t, runtime.NewServeMux(headers), "name", false, http.StatusOK)
+	assert.Contains(t, res, "package")
+
+	opts := append([]runtime.ServeMuxOption{headers}, GatewayOptions()...)
+	res = get(t, runtime.NewServeMux(opts...), "name", false, http.StatusOK)
+	assert.Equal(t, map[string]any{"name": "file.proto"}, res)
+
+	// the responses which are not pruned keep their unpopulated fields
+	res = get(t, runtime.NewServeMux(opts...), "", false, http.StatusOK)
+	assert.Equal(t, "pkg", res["package"])
+	assert.Contains(t, res, "dependency")
+
+	// the response_body routes marshal the pruned body
+	res = get(t, runtime.NewServeMux(opts...), "name,options.java_package", true, http.StatusOK)
+	assert.Equal(t, map[string]any{"javaPackage": "java.pkg"}, res)
+
+	// the error written when a forward response option fails is kept
+	fail := runtime.WithForwardResponseOption(func(context.Context, http.ResponseWriter, proto.Message) error {
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	res = get(t, runtime.NewServeMux(append(opts, fail)...), "name", false, http.StatusServiceUnavailable)
+	assert.Equal(t, "unavailable", res["message"])
+}
+
+// responseBody mimics the generated gateway responses of the routes with a response_body.
+type responseBody struct {
+	*descriptorpb.FileDescriptorProto
+}
+
+func (r *responseBody) XXX_ResponseBody() any {
+	return r.GetOptions()
+}
@@ -0,0 +1,158 @@
+package fieldmask
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// MetadataKey is the incoming metadata key holding the comma separated response mask paths.
+// The gateway forwards it from the X-Field-Mask HTTP header.
+// The unary server interceptor returns the applied paths in the header with the same key, see GatewayOptions.
+const MetadataKey = "x-field-mask"
+
+// NewServerInterceptors returns server interceptors pruning the responses down to the fields selected by the mask
+// read from the request field mask, see WithRequestFields, or from the metadata, see MetadataKey.
+// The request field takes precedence over the metadata.
+// The calls with an invalid mask fail with codes.InvalidArgument, before running the handler when the method
+// descriptor is registered.
+// The streams responses are pruned with the mask of the metadata or of the first received request.
+func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	return &fieldmask{o: o}
+}
+
+type fieldmask struct {
+	o options
+}
+
+func (f *fieldmask) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		paths := f.paths(ctx, req)
+		if len(paths) == 0 {
+			return handler(ctx, req)
+		}
+		if err := validate(info.FullMethod, paths); err != nil {
+			return nil, err
+		}
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, err
+		}
+		m, ok := res.(proto.Message)
+		if !ok {
+			return res, nil
+		}
+		// the response may be shared by the handler, prune a copy
+		m = proto.Clone(m)
+		if err := Prune(m, paths...); err != nil {
+			return nil, err
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, strings.Join(paths, ","))); err != nil {
+			logger.C(ctx).Debugf("failed to set field mask header: %v", err)
+		}
+		return m, nil
+	}
+}
+
+func (f *fieldmask) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		paths := f.paths(ss.Context(), nil)
+		if err := validate(info.FullMethod, paths); err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, f: f, method: info.FullMethod, paths: paths})
+	}
+}
+
+// paths returns the mask paths of the request field, or of the metadata.
+func (f *fieldmask) paths(ctx context.Context, req any) []string {
+	if m, ok := req.(proto.Message); ok {
+		r := m.ProtoReflect()
+		for _, v := range f.o.fields {
+			fd := r.Descriptor().Fields().ByName(protoreflect.Name(v))
+			if fd == nil || fd.Message() == nil || fd.Message().FullName() != "google.protobuf.FieldMask" || !r.Has(fd) {
+				continue
+			}
+			var paths []string
+			switch v := r.Get(fd).Message().Interface().(type) {
+			case *fieldmaskpb.FieldMask:
+				paths = v.GetPaths()
+			default:
+				l := v.ProtoReflect().Get(fd.Message().Fields().ByName("paths")).List()
+				for i := 0; i < l.Len(); i++ {
+					paths = append(paths, l.Get(i).String())
+				}
+			}
+			if len(paths) != 0 {
+				return paths
+			}
+		}
+	}
+	var paths []string
+	for _, v := range metadata.ValueFromIncomingContext(ctx, MetadataKey) {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	return paths
+}
+
+// validate validates the paths against the method output if its descriptor is registered.
+func validate(method string, paths []string) error {
+	if len(paths) == 0 || hasWildcard(paths) {
+		return nil
+	}
+	md, ok := methods.Descriptor(method)
+	if !ok {
+		return nil
+	}
+	_, err := newTree(md.Output(), paths)
+	return err
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	f      *fieldmask
+	method string
+	paths  []string
+	recv   bool
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.recv || len(s.paths) != 0 {
+		return nil
+	}
+	s.recv = true
+	s.paths = s.f.paths(context.Background(), m)
+	return validate(s.method, s.paths)
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	if pm, ok := m.(proto.Message); ok && len(s.paths) != 0 {
+		// the message may be reused by the handler, prune a copy
+		pm = proto.Clone(pm)
+		if err := Prune(pm, s.paths...); err != nil {
+			return err
+		}
+		m = pm
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,19 @@
+package fieldmask
+
+var defaultOptions = options{
+	fields: []string{"read_mask", "field_mask"},
+}
+
+type Option func(*options)
+
+// WithRequestFields sets the names of the request google.protobuf.FieldMask fields holding the response mask.
+// It defaults to read_mask and field_mask.
+func WithRequestFields(names ...string) Option {
+	return func(o *options) {
+		o.fields = names
+	}
+}
+
+type options struct {
+	fields []string
+}
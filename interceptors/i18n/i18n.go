@@ -0,0 +1,219 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+// AcceptLanguageHeader is the metadata key of the accepted languages, forwarded by the gateway.
+const AcceptLanguageHeader = "accept-language"
+
+// Catalog holds the localized messages of the errors by locale and ErrorInfo reason, e.g.:
+//
+//	{"fr": {"RATE_LIMITED": "Trop de requêtes, réessayez dans {delay}."}}
+//
+// The messages may reference the ErrorInfo metadata values with {key}.
+type Catalog map[string]map[string]string
+
+// ParseCatalog parses a JSON catalog.
+func ParseCatalog(b []byte) (Catalog, error) {
+	var c Catalog
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewServerInterceptors returns server interceptors adding a LocalizedMessage to the errors having
+// an ErrorInfo with a reason found in the catalogs, in the best locale matching the accept-language metadata.
+// The errors already carrying a LocalizedMessage are left untouched.
+// The config catalog is watched until the context is done.
+func NewServerInterceptors(ctx context.Context, opts ...Option) (interceptors.ServerInterceptors, error) {
+	l := &localizer{}
+	for _, o := range opts {
+		o(&l.o)
+	}
+	base := Catalog{}
+	for _, v := range l.o.catalogs {
+		base.merge(v)
+	}
+	for _, v := range l.o.fs {
+		c, err := loadFS(v.fsys, v.pattern)
+		if err != nil {
+			return nil, err
+		}
+		base.merge(c)
+	}
+	if l.o.config == nil {
+		return l, l.update(base)
+	}
+	b, err := l.o.config.Read()
+	if err != nil {
+		return nil, err
+	}
+	c, err := ParseCatalog(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.update(base, c); err != nil {
+		return nil, err
+	}
+	updates := make(chan []byte)
+	if err := l.o.config.Watch(ctx, updates); err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b := <-updates:
+				c, err := ParseCatalog(b)
+				if err == nil {
+					err = l.update(base, c)
+				}
+				if err != nil {
+					logger.C(ctx).WithError(err).Error("failed to load errors messages catalog")
+				}
+			}
+		}
+	}()
+	return l, nil
+}
+
+func (c Catalog) merge(o Catalog) {
+	for locale, messages := range o {
+		if c[locale] == nil {
+			c[locale] = make(map[string]string, len(messages))
+		}
+		for k, v := range messages {
+			c[locale][k] = v
+		}
+	}
+}
+
+func loadFS(fsys fs.FS, pattern string) (Catalog, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	c := Catalog{}
+	for _, f := range files {
+		b, err := fs.ReadFile(fsys, f)
+		if err != nil {
+			return nil, err
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		c.merge(Catalog{strings.TrimSuffix(path.Base(f), path.Ext(f)): messages})
+	}
+	return c, nil
+}
+
+type localizer struct {
+	o       options
+	catalog atomic.Pointer[catalog]
+}
+
+type catalog struct {
+	tags     []language.Tag
+	messages []map[string]string
+	matcher  language.Matcher
+	// fallback reports whether the first tag is the default locale
+	fallback bool
+}
+
+func (l *localizer) update(catalogs ...Catalog) error {
+	merged := Catalog{}
+	for _, v := range catalogs {
+		merged.merge(v)
+	}
+	c := &catalog{}
+	if l.o.defaultLocale != "" {
+		if _, ok := merged[l.o.defaultLocale]; !ok {
+			return fmt.Errorf("no messages for the default locale %s", l.o.defaultLocale)
+		}
+		c.fallback = true
+	}
+	// the matcher defaults to the first tag
+	locales := []string{l.o.defaultLocale}
+	for k := range merged {
+		if k != l.o.defaultLocale {
+			locales = append(locales, k)
+		}
+	}
+	if l.o.defaultLocale == "" {
+		locales = locales[1:]
+	}
+	for _, v := range locales {
+		t, err := language.Parse(v)
+		if err != nil {
+			return fmt.Errorf("invalid locale %s: %w", v, err)
+		}
+		c.tags = append(c.tags, t)
+		c.messages = append(c.messages, merged[v])
+	}
+	c.matcher = language.NewMatcher(c.tags)
+	l.catalog.Store(c)
+	return nil
+}
+
+func (l *localizer) localize(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	info, ok := errors.ErrorInfo(err)
+	if !ok || info.GetReason() == "" {
+		return err
+	}
+	if _, ok := errors.LocalizedMessage(err, ""); ok {
+		return err
+	}
+	c := l.catalog.Load()
+	if len(c.tags) == 0 {
+		return err
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	accepted, _, _ := language.ParseAcceptLanguage(strings.Join(md.Get(AcceptLanguageHeader), ","))
+	_, i, conf := c.matcher.Match(accepted...)
+	if conf == language.No && !c.fallback {
+		return err
+	}
+	msg, ok := c.messages[i][info.GetReason()]
+	if !ok {
+		return err
+	}
+	for k, v := range info.GetMetadata() {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return errors.WithDetails(err, &errdetails.LocalizedMessage{Locale: c.tags[i].String(), Message: msg})
+}
+
+func (l *localizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		return res, l.localize(ctx, err)
+	}
+}
+
+func (l *localizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return l.localize(ss.Context(), handler(srv, ss))
+	}
+}
@@ -0,0 +1,115 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+type testConfig struct {
+	b       []byte
+	updates chan<- []byte
+	watched chan struct{}
+}
+
+func (c *testConfig) Read() ([]byte, error) {
+	return c.b, nil
+}
+
+func (c *testConfig) Watch(ctx context.Context, updates chan<- []byte) error {
+	c.updates = updates
+	close(c.watched)
+	return nil
+}
+
+func call(t *testing.T, i grpc.UnaryServerInterceptor, lang string, err error) (string, string) {
+	ctx := context.Background()
+	if lang != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AcceptLanguageHeader, lang))
+	}
+	_, err = i(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		return nil, err
+	})
+	require.Error(t, err)
+	m, ok := errors.LocalizedMessage(err, "")
+	if !ok {
+		return "", ""
+	}
+	return m.GetLocale(), m.GetMessage()
+}
+
+func TestInterceptors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fsys := fstest.MapFS{
+		"locales/fr.json": {Data: []byte(`{"RATE_LIMITED": "Trop de requêtes, limite: {limit}"}`)},
+		"locales/en.json": {Data: []byte(`{"RATE_LIMITED": "Too many requests, limit: {limit}"}`)},
+	}
+	conf := &testConfig{b: []byte(`{"de": {"RATE_LIMITED": "Zu viele Anfragen"}}`), watched: make(chan struct{})}
+	in, err := NewServerInterceptors(ctx, WithFS(fsys, "locales/*.json"), WithConfig(conf), WithDefaultLocale("en"))
+	require.NoError(t, err)
+	i := in.UnaryServerInterceptor()
+	limited := errors.Build(codes.ResourceExhausted, "too many requests").ErrorInfo("RATE_LIMITED", "example.com", map[string]string{"limit": "10"}).Err()
+
+	locale, msg := call(t, i, "fr-CH, fr;q=0.9, en;q=0.8", limited)
+	assert.Equal(t, "fr", locale)
+	assert.Equal(t, "Trop de requêtes, limite: 10", msg)
+
+	locale, msg = call(t, i, "de", limited)
+	assert.Equal(t, "de", locale)
+	assert.Equal(t, "Zu viele Anfragen", msg)
+
+	locale, _ = call(t, i, "ja", limited)
+	assert.Equal(t, "en", locale)
+	locale, _ = call(t, i, "", limited)
+	assert.Equal(t, "en", locale)
+
+	_, msg = call(t, i, "fr", errors.Build(codes.Internal, "internal").ErrorInfo("UNKNOWN_REASON", "example.com", nil).Err())
+	assert.Empty(t, msg)
+	_, msg = call(t, i, "fr", errors.Internalf("internal"))
+	assert.Empty(t, msg)
+	_, msg = call(t, i, "fr", errors.Build(codes.ResourceExhausted, "limited").ErrorInfo("RATE_LIMITED", "", nil).LocalizedMessage("es", "Demasiadas solicitudes").Err())
+	assert.Equal(t, "Demasiadas solicitudes", msg)
+
+	// the localized errors keep their cause
+	cause := errors.Wrap(codes.ResourceExhausted, fmt.Errorf("limited"), &errdetails.ErrorInfo{Reason: "RATE_LIMITED"})
+	_, err = i(metadata.NewIncomingContext(ctx, metadata.Pairs(AcceptLanguageHeader, "fr")), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		return nil, cause
+	})
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "limited", status.Convert(err).Message())
+	_, ok := errors.LocalizedMessage(err, "fr")
+	assert.True(t, ok)
+
+	<-conf.watched
+	conf.updates <- []byte(`{"de": {"RATE_LIMITED": "Zu viele Anfragen (neu)"}}`)
+	assert.Eventually(t, func() bool {
+		_, msg := call(t, i, "de", limited)
+		return msg == "Zu viele Anfragen (neu)"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNoDefaultLocale(t *testing.T) {
+	in, err := NewServerInterceptors(context.Background(), WithCatalog(Catalog{"fr": {"RATE_LIMITED": "Trop de requêtes"}}))
+	require.NoError(t, err)
+	limited := errors.Build(codes.ResourceExhausted, "too many requests").ErrorInfo("RATE_LIMITED", "example.com", nil).Err()
+	_, msg := call(t, in.UnaryServerInterceptor(), "en", limited)
+	assert.Empty(t, msg)
+	_, msg = call(t, in.UnaryServerInterceptor(), "fr-FR", limited)
+	assert.Equal(t, "Trop de requêtes", msg)
+
+	_, err = NewServerInterceptors(context.Background(), WithCatalog(Catalog{"fr": {}}), WithDefaultLocale("en"))
+	assert.Error(t, err)
+}
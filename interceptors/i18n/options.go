@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"io/fs"
+
+	"go.linka.cloud/grpc-toolkit/config"
+)
+
+type Option func(*options)
+
+// WithCatalog adds the messages of the catalog.
+func WithCatalog(c Catalog) Option {
+	return func(o *options) {
+		o.catalogs = append(o.catalogs, c)
+	}
+}
+
+// WithFS loads the messages from the JSON files of fsys matching the pattern, e.g. an embed.FS and "locales/*.json".
+// Each file holds the messages of the locale named after the file, e.g. locales/fr-CA.json:
+//
+//	{"RATE_LIMITED": "Trop de requêtes, réessayez dans {delay}."}
+func WithFS(fsys fs.FS, pattern string) Option {
+	return func(o *options) {
+		o.fs = append(o.fs, fsFiles{fsys: fsys, pattern: pattern})
+	}
+}
+
+// WithConfig loads the messages from a JSON Catalog provided by the config, reloading them when it changes.
+// The config messages take precedence over the other ones.
+func WithConfig(conf config.Config) Option {
+	return func(o *options) {
+		o.config = conf
+	}
+}
+
+// WithDefaultLocale sets the locale used when none of the accepted languages is available.
+// Without default locale, the errors are not localized in that case.
+func WithDefaultLocale(locale string) Option {
+	return func(o *options) {
+		o.defaultLocale = locale
+	}
+}
+
+type fsFiles struct {
+	fsys    fs.FS
+	pattern string
+}
+
+type options struct {
+	catalogs      []Catalog
+	fs            []fsFiles
+	config        config.Config
+	defaultLocale string
+}
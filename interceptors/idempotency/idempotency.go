@@ -0,0 +1,189 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+const (
+	// KeyHeader is the incoming metadata key holding the call idempotency key.
+	KeyHeader = "idempotency-key"
+	// ReplayedHeader is the header sent with the responses replayed from the store.
+	ReplayedHeader = "idempotency-replayed"
+)
+
+// NewServerInterceptors returns server interceptors making the unary calls carrying an idempotency key,
+// see KeyHeader, execute at most once.
+//
+// The response or the status of the completed calls is stored under a key made of the principal,
+// the method and the idempotency key, and replayed to the following calls with the same key.
+// The calls failing with a transient error, i.e. Canceled, DeadlineExceeded, Unavailable, Aborted
+// or ResourceExhausted, are not stored and can be retried.
+// The duplicates of a call in flight fail with codes.Aborted unless WithWait is used.
+// Reusing a key with a different request fails with codes.FailedPrecondition.
+// The interceptors must be installed after the auth interceptors for the keys to be bound to the principal.
+// Streams are not supported.
+func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	if o.store == nil {
+		o.store = NewMemoryStore()
+	}
+	return &idempotency{o: o}
+}
+
+type idempotency struct {
+	o options
+}
+
+func (i *idempotency) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var key string
+		if v := metadata.ValueFromIncomingContext(ctx, KeyHeader); len(v) != 0 {
+			key = v[0]
+		}
+		if key == "" {
+			if methods.MatchAny(i.o.required, info.FullMethod) {
+				return nil, errors.InvalidArgumentf("missing %s", KeyHeader)
+			}
+			return handler(ctx, req)
+		}
+		m, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+		if err != nil {
+			return nil, errors.Internalf("failed to marshal request: %v", err)
+		}
+		hash := sha256.Sum256(b)
+		k := i.key(ctx, info.FullMethod, key)
+		var token string
+		for {
+			r, t, err := i.o.store.Reserve(ctx, k, hash[:], i.o.reservationTTL)
+			if err == ErrMismatch {
+				return nil, mismatch()
+			}
+			if err != nil {
+				return nil, errors.Unavailablef("idempotency store: %v", err)
+			}
+			if r != nil {
+				return i.replay(ctx, info.FullMethod, hash[:], r)
+			}
+			if t != "" {
+				token = t
+				break
+			}
+			if i.o.wait <= 0 {
+				return nil, errors.Abortedf("a call with the same %s is in progress", KeyHeader)
+			}
+			select {
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			case <-time.After(i.o.wait):
+			}
+		}
+		res, err := handler(ctx, req)
+		// the store must be updated even if the call was canceled
+		sctx := context.WithoutCancel(ctx)
+		r := &Record{Request: hash[:]}
+		var merr error
+		if err != nil {
+			r.Status, merr = proto.Marshal(status.Convert(err).Proto())
+		} else if m, ok := res.(proto.Message); ok {
+			r.Response, merr = proto.Marshal(m)
+		} else {
+			merr = errors.Internalf("response is not a proto.Message")
+		}
+		if transient(err) || merr != nil {
+			if err := i.o.store.Release(sctx, k, token); err != nil {
+				logger.C(ctx).WithError(err).Warnf("failed to release idempotency key")
+			}
+			return res, err
+		}
+		if err := i.o.store.Complete(sctx, k, token, r, i.o.ttl); err != nil {
+			logger.C(ctx).WithError(err).Warnf("failed to store idempotency record")
+		}
+		return res, err
+	}
+}
+
+func (i *idempotency) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+}
+
+func (i *idempotency) key(ctx context.Context, method, key string) string {
+	h := sha256.New()
+	if p, ok := auth.PrincipalFrom(ctx); ok {
+		h.Write([]byte(p.Method))
+		h.Write([]byte{0})
+		h.Write([]byte(p.Subject))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (i *idempotency) replay(ctx context.Context, method string, hash []byte, r *Record) (any, error) {
+	if !bytes.Equal(hash, r.Request) {
+		return nil, mismatch()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(ReplayedHeader, "true")); err != nil {
+		logger.C(ctx).Debugf("failed to set replayed header: %v", err)
+	}
+	if r.Status != nil {
+		s := &spb.Status{}
+		if err := proto.Unmarshal(r.Status, s); err != nil {
+			return nil, errors.Internalf("failed to unmarshal stored status: %v", err)
+		}
+		return nil, status.FromProto(s).Err()
+	}
+	md, ok := methods.Descriptor(method)
+	if !ok {
+		return nil, errors.Internalf("%s: method descriptor not found", method)
+	}
+	typ, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+	if err != nil {
+		return nil, errors.Internalf("%s: %v", method, err)
+	}
+	res := typ.New().Interface()
+	if err := proto.Unmarshal(r.Response, res); err != nil {
+		return nil, errors.Internalf("failed to unmarshal stored response: %v", err)
+	}
+	return res, nil
+}
+
+func mismatch() error {
+	return errors.FailedPreconditionf("%s already used with a different request", KeyHeader)
+}
+
+func transient(err error) bool {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
+)
+
+const method = "/idempotency.test.Service/Pay"
+
+func init() {
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("interceptors/idempotency/idempotency_test.proto"),
+		Package:    proto.String("idempotency.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Service"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Pay"),
+				InputType:  proto.String(".google.protobuf.StringValue"),
+				OutputType: proto.String(".google.protobuf.StringValue"),
+			}},
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+}
+
+type server struct {
+	i     grpc.UnaryServerInterceptor
+	calls atomic.Int32
+	err   error
+	wait  chan struct{}
+}
+
+func (s *server) call(ctx context.Context, key, req string) (string, error) {
+	if key != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(KeyHeader, key))
+	}
+	res, err := s.i(ctx, wrapperspb.String(req), &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		n := s.calls.Add(1)
+		if s.wait != nil {
+			<-s.wait
+		}
+		if s.err != nil {
+			return nil, s.err
+		}
+		return wrapperspb.String(req.(*wrapperspb.StringValue).Value + ":" + string('0'+n)), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.(*wrapperspb.StringValue).Value, nil
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	s := &server{i: NewServerInterceptors(WithRequiredMethods("/idempotency.test.Service/*")).UnaryServerInterceptor()}
+
+	res, err := s.call(ctx, "a", "pay")
+	require.NoError(t, err)
+	assert.Equal(t, "pay:1", res)
+	res, err = s.call(ctx, "a", "pay")
+	require.NoError(t, err)
+	assert.Equal(t, "pay:1", res)
+
+	_, err = s.call(ctx, "a", "other")
+	assert.True(t, errors.IsFailedPrecondition(err))
+	_, err = s.call(ctx, "", "pay")
+	assert.True(t, errors.IsInvalidArgument(err))
+
+	// another principal does not share the key
+	pctx := auth.ContextWithPrincipal(ctx, &auth.Principal{Subject: "user"})
+	res, err = s.call(pctx, "a", "pay")
+	require.NoError(t, err)
+	assert.Equal(t, "pay:2", res)
+	assert.Equal(t, int32(2), s.calls.Load())
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	s := &server{i: NewServerInterceptors().UnaryServerInterceptor(), err: errors.FailedPreconditionf("insufficient funds")}
+	for range 2 {
+		_, err := s.call(ctx, "a", "pay")
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, "insufficient funds", status.Convert(err).Message())
+	}
+	assert.Equal(t, int32(1), s.calls.Load())
+
+	s.err = errors.Unavailablef("unavailable")
+	for range 2 {
+		_, err := s.call(ctx, "b", "pay")
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	assert.Equal(t, int32(3), s.calls.Load())
+}
+
+func TestConcurrent(t *testing.T) {
+	test := func(t *testing.T, wait bool) {
+		ctx := context.Background()
+		var opts []Option
+		if wait {
+			opts = append(opts, WithWait(10*time.Millisecond))
+		}
+		s := &server{i: NewServerInterceptors(opts...).UnaryServerInterceptor(), wait: make(chan struct{})}
+		var wg sync.WaitGroup
+		errs := make([]error, 3)
+		for n := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, errs[n] = s.call(ctx, "a", "pay")
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(s.wait)
+		wg.Wait()
+		assert.Equal(t, int32(1), s.calls.Load())
+		var aborted int
+		for _, err := range errs {
+			if errors.IsAborted(err) {
+				aborted++
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+		if wait {
+			assert.Zero(t, aborted)
+		} else {
+			assert.Equal(t, 2, aborted)
+		}
+	}
+	t.Run("aborted", func(t *testing.T) { test(t, false) })
+	t.Run("wait", func(t *testing.T) { test(t, true) })
+}
+
+func TestConcurrentMismatch(t *testing.T) {
+	ctx := context.Background()
+	s := &server{i: NewServerInterceptors(WithWait(10 * time.Millisecond)).UnaryServerInterceptor(), wait: make(chan struct{})}
+	done := make(chan error)
+	go func() {
+		_, err := s.call(ctx, "a", "pay")
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return s.calls.Load() == 1 }, time.Second, time.Millisecond)
+	_, err := s.call(ctx, "a", "other")
+	assert.True(t, errors.IsFailedPrecondition(err))
+	close(s.wait)
+	require.NoError(t, <-done)
+}
+
+func TestMemoryRelease(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore()
+	_, stale, err := m.Reserve(ctx, "a", []byte("pay"), time.Millisecond)
+	require.NoError(t, err)
+	require.NotEmpty(t, stale)
+	time.Sleep(2 * time.Millisecond)
+	// the expired reservation is taken by another call
+	_, token, err := m.Reserve(ctx, "a", []byte("pay"), time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	// the stale token does not release nor complete the new reservation
+	require.NoError(t, m.Release(ctx, "a", stale))
+	require.NoError(t, m.Complete(ctx, "a", stale, &Record{Request: []byte("pay")}, time.Minute))
+	r, t2, err := m.Reserve(ctx, "a", []byte("pay"), time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	assert.Empty(t, t2)
+
+	require.NoError(t, m.Release(ctx, "a", token))
+	_, t2, err = m.Reserve(ctx, "a", []byte("pay"), time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, t2)
+}
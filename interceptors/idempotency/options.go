@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"time"
+)
+
+const (
+	// DefaultTTL is the default retention of the completed calls records.
+	DefaultTTL = 24 * time.Hour
+	// DefaultReservationTTL is the default maximum duration of the reservation of a key by a call in flight.
+	DefaultReservationTTL = 5 * time.Minute
+)
+
+var defaultOptions = options{
+	ttl:            DefaultTTL,
+	reservationTTL: DefaultReservationTTL,
+}
+
+type Option func(*options)
+
+// WithStore sets the store holding the records. It defaults to an in-memory store.
+func WithStore(s Store) Option {
+	return func(o *options) {
+		o.store = s
+	}
+}
+
+// WithTTL sets the retention of the completed calls records. It defaults to DefaultTTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithReservationTTL sets the maximum duration of the reservation of a key by a call in flight,
+// after which a duplicate call is executed. It defaults to DefaultReservationTTL.
+func WithReservationTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.reservationTTL = ttl
+	}
+}
+
+// WithWait makes the duplicates of a call in flight wait for its completion, checking the store at the given interval,
+// instead of failing with codes.Aborted.
+func WithWait(interval time.Duration) Option {
+	return func(o *options) {
+		o.wait = interval
+	}
+}
+
+// WithRequiredMethods rejects the calls to the given methods without an idempotency key.
+// It takes a list of fully qualified method names, e.g. /payments.Payments/Charge,
+// or service wildcards, e.g. /payments.Payments/*.
+func WithRequiredMethods(methods ...string) Option {
+	return func(o *options) {
+		o.required = append(o.required, methods...)
+	}
+}
+
+type options struct {
+	store          Store
+	ttl            time.Duration
+	reservationTTL time.Duration
+	wait           time.Duration
+	required       []string
+}
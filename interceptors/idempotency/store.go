@@ -0,0 +1,114 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrMismatch is returned by Store.Reserve when the key is reserved by a call in flight with another request.
+var ErrMismatch = errors.New("key reserved with a different request")
+
+// Record is the outcome of a completed call.
+type Record struct {
+	// Request is the hash of the request, used to detect the reuse of a key with another request.
+	Request []byte
+	// Response is the marshaled response of the successful call.
+	Response []byte
+	// Status is the marshaled google.rpc.Status of the failed call.
+	Status []byte
+}
+
+// Store stores the completed calls records and the reservations of the calls in flight.
+type Store interface {
+	// Reserve reserves the key for a call in flight with the request hash until ttl elapses,
+	// and returns the reservation token.
+	// It returns the record of the completed call if the key holds one, an empty token if the key
+	// is already reserved by another call in flight, or ErrMismatch if that call has another request hash.
+	Reserve(ctx context.Context, key string, request []byte, ttl time.Duration) (r *Record, token string, err error)
+	// Complete stores the record of the completed call for ttl, replacing the key reservation
+	// if it is still held with the token.
+	Complete(ctx context.Context, key, token string, r *Record, ttl time.Duration) error
+	// Release removes the key reservation if it is still held with the token.
+	Release(ctx context.Context, key, token string) error
+}
+
+// NewMemoryStore returns an in-memory Store. The expired entries are removed at most once per minute.
+func NewMemoryStore() Store {
+	return &memory{entries: make(map[string]*entry)}
+}
+
+type entry struct {
+	// record is nil while the call is in flight
+	record  *Record
+	request []byte
+	token   string
+	expires time.Time
+}
+
+type memory struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	swept   time.Time
+}
+
+func (m *memory) Reserve(_ context.Context, key string, request []byte, ttl time.Duration) (*Record, string, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.sweep(now)
+	if e, ok := m.entries[key]; ok && e.expires.After(now) {
+		if e.record == nil && !bytes.Equal(e.request, request) {
+			return nil, "", ErrMismatch
+		}
+		return e.record, "", nil
+	}
+	m.entries[key] = &entry{request: request, token: token, expires: now.Add(ttl)}
+	return nil, token, nil
+}
+
+func (m *memory) Complete(_ context.Context, key, token string, r *Record, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok && e.record == nil && e.token == token {
+		m.entries[key] = &entry{record: r, expires: time.Now().Add(ttl)}
+	}
+	return nil
+}
+
+func (m *memory) Release(_ context.Context, key, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok && e.record == nil && e.token == token {
+		delete(m.entries, key)
+	}
+	return nil
+}
+
+func (m *memory) sweep(now time.Time) {
+	if now.Sub(m.swept) < time.Minute {
+		return
+	}
+	m.swept = now
+	for k, e := range m.entries {
+		if !e.expires.After(now) {
+			delete(m.entries, k)
+		}
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
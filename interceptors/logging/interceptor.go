@@ -2,42 +2,158 @@ package logging
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
 	"go.linka.cloud/grpc-toolkit/interceptors/chain"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
 	"go.linka.cloud/grpc-toolkit/logger"
 )
 
+// New returns interceptors logging the calls. The fields describing the auth principal are added
+// when the auth interceptors run before these ones, unless the options set another fields from context func.
+// The logged payloads are redacted from their sensitive fields.
 func New(ctx context.Context, opts ...logging.Option) interceptors.Interceptors {
-	log := logger.C(ctx)
-	return &interceptor{
-		log: logging.LoggerFunc(func(ctx context.Context, level logging.Level, msg string, fields ...any) {
-			switch level {
-			case logging.LevelDebug:
-				log.WithReportCaller(true, 2).WithFields(fields...).Debug(msg)
-			case logging.LevelInfo:
-				log.WithReportCaller(true, 2).WithFields(fields...).Info(msg)
-			case logging.LevelWarn:
-				log.WithReportCaller(true, 2).WithFields(fields...).Warn(msg)
-			case logging.LevelError:
-				log.WithReportCaller(true, 2).WithFields(fields...).Error(msg)
+	return NewInterceptors(ctx, WithOptions(opts...))
+}
+
+// NewInterceptors is like New, with the payloads, sampling, slow calls and levels options.
+func NewInterceptors(ctx context.Context, opts ...Option) interceptors.Interceptors {
+	i := &interceptor{log: logger.C(ctx)}
+	for _, v := range opts {
+		v(&i.o)
+	}
+	events := []logging.Option{logging.WithFieldsFromContext(func(ctx context.Context) logging.Fields {
+		return auth.PrincipalFields(ctx)
+	})}
+	if len(i.o.requests) != 0 || len(i.o.responses) != 0 {
+		events = append(events, logging.WithLogOnEvents(logging.StartCall, logging.FinishCall, logging.PayloadReceived, logging.PayloadSent))
+	}
+	i.opts = append(events, i.o.opts...)
+	return i
+}
+
+type callKey struct{}
+
+// call holds the call state shared by its log lines.
+type call struct {
+	method  string
+	start   time.Time
+	sampled bool
+}
+
+func (i *interceptor) withCall(ctx context.Context, method string) context.Context {
+	sampled := true
+	if i.o.sampling > 1 {
+		sampled = (i.count.Add(1)-1)%uint64(i.o.sampling) == 0
+	}
+	return context.WithValue(ctx, callKey{}, &call{method: method, start: time.Now(), sampled: sampled})
+}
+
+func (i *interceptor) Log(ctx context.Context, level logging.Level, msg string, fields ...any) {
+	c, _ := ctx.Value(callKey{}).(*call)
+	code, finished := codes.OK, false
+	var out []any
+	for k := 0; k+1 < len(fields); k += 2 {
+		switch fields[k] {
+		case "grpc.code":
+			code, finished = parseCode(fields[k+1]), true
+		case "grpc.request.content", "grpc.response.content":
+			list := i.o.requests
+			if fields[k] == "grpc.response.content" {
+				list = i.o.responses
+			}
+			if c != nil && (len(i.o.requests) != 0 || len(i.o.responses) != 0) && !methods.MatchAny(list, c.method) {
+				return
 			}
-		}),
-		opts: opts,
+			if out == nil {
+				out = append([]any(nil), fields...)
+			}
+			out[k+1] = i.payload(fields[k+1])
+		}
+	}
+	if out != nil {
+		fields = out
+	}
+	if c != nil {
+		slow := finished && i.o.slow > 0 && time.Since(c.start) >= i.o.slow
+		if !c.sampled && !(finished && (code != codes.OK || slow)) {
+			return
+		}
+		if i.o.level != nil {
+			level = i.o.level(c.method, code)
+		}
+		if slow {
+			level = max(level, logging.LevelWarn)
+			fields = append(fields[:len(fields):len(fields)], "grpc.slow", true)
+		}
+	}
+	// report the go-grpc-middleware caller of Log
+	log := i.log.WithReportCaller(true, 1).WithFields(fields...)
+	switch level {
+	case logging.LevelDebug:
+		log.Debug(msg)
+	case logging.LevelInfo:
+		log.Info(msg)
+	case logging.LevelWarn:
+		log.Warn(msg)
+	case logging.LevelError:
+		log.Error(msg)
 	}
 }
 
+// payload returns the redacted payload, encoded to JSON and truncated if a max size is set.
+func (i *interceptor) payload(v any) any {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return v
+	}
+	m = i.o.redactor.Redact(m)
+	if i.o.maxSize <= 0 {
+		return m
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return m
+	}
+	if len(b) <= i.o.maxSize {
+		return string(b)
+	}
+	return strings.ToValidUTF8(string(b[:i.o.maxSize]), "") + "..."
+}
+
+func parseCode(v any) codes.Code {
+	s, _ := v.(string)
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if c.String() == s {
+			return c
+		}
+	}
+	return codes.Unknown
+}
+
 type interceptor struct {
-	log  logging.Logger
-	opts []logging.Option
+	o     options
+	log   logger.Logger
+	opts  []logging.Option
+	count atomic.Uint64
 }
 
 func (i *interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return chain.UnaryServer(
-		logging.UnaryServerInterceptor(i.log, i.opts...),
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+			return handler(i.withCall(ctx, info.FullMethod), req)
+		},
+		logging.UnaryServerInterceptor(i, i.opts...),
 		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
 			log := logger.C(ctx)
 			return handler(logger.Set(ctx, log.WithFields(logging.ExtractFields(ctx)...)), req)
@@ -47,7 +163,10 @@ func (i *interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 
 func (i *interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
 	return chain.StreamServer(
-		logging.StreamServerInterceptor(i.log, i.opts...),
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, interceptors.NewContextServerStream(i.withCall(ss.Context(), info.FullMethod), ss))
+		},
+		logging.StreamServerInterceptor(i, i.opts...),
 		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 			ctx := ss.Context()
 			log := logger.C(ctx)
@@ -58,7 +177,10 @@ func (i *interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
 
 func (i *interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return chain.UnaryClient(
-		logging.UnaryClientInterceptor(i.log, i.opts...),
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(i.withCall(ctx, method), method, req, reply, cc, opts...)
+		},
+		logging.UnaryClientInterceptor(i, i.opts...),
 		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 			log := logger.C(ctx)
 			return invoker(logger.Set(ctx, log.WithFields(logging.ExtractFields(ctx)...)), method, req, reply, cc, opts...)
@@ -68,7 +190,10 @@ func (i *interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 
 func (i *interceptor) StreamClientInterceptor() grpc.StreamClientInterceptor {
 	return chain.StreamClient(
-		logging.StreamClientInterceptor(i.log, i.opts...),
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(i.withCall(ctx, method), desc, cc, method, opts...)
+		},
+		logging.StreamClientInterceptor(i, i.opts...),
 		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 			log := logger.C(ctx)
 			return streamer(logger.Set(ctx, log.WithFields(logging.ExtractFields(ctx)...)), desc, cc, method, opts...)
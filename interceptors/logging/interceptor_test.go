@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/logger"
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+func newTest(opts ...Option) (grpc.UnaryServerInterceptor, *test.Hook) {
+	l, h := test.NewNullLogger()
+	l.SetLevel(logrus.DebugLevel)
+	ctx := logger.Set(context.Background(), logger.FromLogrus(l))
+	return NewInterceptors(ctx, opts...).UnaryServerInterceptor(), h
+}
+
+func do(i grpc.UnaryServerInterceptor, method string, err error, d time.Duration) {
+	i(context.Background(), wrapperspb.String(strings.Repeat("a", 100)), &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+		time.Sleep(d)
+		return wrapperspb.String("response"), err
+	})
+}
+
+func messages(h *test.Hook) (out []string) {
+	for _, v := range h.AllEntries() {
+		out = append(out, v.Message)
+	}
+	return out
+}
+
+func TestCaller(t *testing.T) {
+	l, h := test.NewNullLogger()
+	ctx := logger.Set(context.Background(), logger.FromLogrus(l))
+	i := New(ctx, logging.WithLogOnEvents(logging.FinishCall)).UnaryServerInterceptor()
+	do(i, "/svc/Method", nil, 0)
+	require.Len(t, h.AllEntries(), 1)
+	assert.Contains(t, h.LastEntry().Data["caller"], "go-grpc-middleware/v2/interceptors/logging/")
+}
+
+func TestRedactedPayloads(t *testing.T) {
+	r := redact.New(redact.WithPaths(&wrapperspb.StringValue{}, "value"))
+	i, h := newTest(WithRequestPayloads(), WithRedactor(r))
+	do(i, "/svc/Method", nil, 0)
+	require.Len(t, h.AllEntries(), 3)
+	content, ok := h.AllEntries()[1].Data["grpc.request.content"].(proto.Message)
+	require.True(t, ok)
+	assert.True(t, proto.Equal(wrapperspb.String(redact.Placeholder), content))
+}
+
+func TestPayloads(t *testing.T) {
+	i, h := newTest(WithRequestPayloads("/svc/Req"), WithResponsePayloads(), WithPayloadMaxSize(20))
+	do(i, "/svc/Req", nil, 0)
+	assert.Equal(t, []string{"started call", "request received", "response sent", "finished call"}, messages(h))
+	assert.Equal(t, `"aaaaaaaaaaaaaaaaaaa...`, h.AllEntries()[1].Data["grpc.request.content"])
+	assert.Equal(t, `"response"`, h.AllEntries()[2].Data["grpc.response.content"])
+
+	h.Reset()
+	do(i, "/svc/Other", nil, 0)
+	assert.Equal(t, []string{"started call", "response sent", "finished call"}, messages(h))
+}
+
+func TestSampling(t *testing.T) {
+	i, h := newTest(WithSampling(3), WithSlowThreshold(50*time.Millisecond))
+	for range 7 {
+		do(i, "/svc/Method", nil, 0)
+	}
+	assert.Len(t, h.AllEntries(), 6)
+
+	h.Reset()
+	do(i, "/svc/Method", nil, 0)
+	do(i, "/svc/Method", status.Error(codes.Internal, "internal"), 0)
+	require.Len(t, h.AllEntries(), 1)
+	assert.Equal(t, logrus.ErrorLevel, h.LastEntry().Level)
+
+	// sampled
+	do(i, "/svc/Method", nil, 0)
+	h.Reset()
+	do(i, "/svc/Method", nil, 60*time.Millisecond)
+	require.Len(t, h.AllEntries(), 1)
+	assert.Equal(t, logrus.WarnLevel, h.LastEntry().Level)
+	assert.Equal(t, true, h.LastEntry().Data["grpc.slow"])
+}
+
+func TestLevelDecider(t *testing.T) {
+	i, h := newTest(WithLevelDecider(func(fullMethod string, code codes.Code) logging.Level {
+		if fullMethod == "/svc/Debug" && code == codes.OK {
+			return logging.LevelDebug
+		}
+		return logging.LevelError
+	}))
+	do(i, "/svc/Debug", nil, 0)
+	do(i, "/svc/Debug", status.Error(codes.NotFound, "not found"), 0)
+	levels := func() (out []logrus.Level) {
+		for _, v := range h.AllEntries() {
+			out = append(out, v.Level)
+		}
+		return out
+	}
+	assert.Equal(t, []logrus.Level{logrus.DebugLevel, logrus.DebugLevel, logrus.DebugLevel, logrus.ErrorLevel}, levels())
+}
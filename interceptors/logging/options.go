@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc/codes"
+
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+type Option func(*options)
+
+// WithOptions passes the options to the go-grpc-middleware logging interceptors.
+func WithOptions(opts ...logging.Option) Option {
+	return func(o *options) {
+		o.opts = append(o.opts, opts...)
+	}
+}
+
+// WithRequestPayloads enables the logging of the requests payloads of the given methods, or of all methods if none is given.
+// It takes a list of fully qualified method names, e.g. /grpc.health.v1.Health/Check, or wildcards, e.g. /grpc.health.v1.Health/*.
+// The payloads are redacted from their sensitive fields.
+func WithRequestPayloads(methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		o.requests = append(o.requests, methods...)
+	}
+}
+
+// WithResponsePayloads enables the logging of the responses payloads of the given methods, or of all methods if none is given.
+// See WithRequestPayloads.
+func WithResponsePayloads(methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		o.responses = append(o.responses, methods...)
+	}
+}
+
+// WithRedactor sets the redactor of the logged payloads.
+// It defaults to redacting the fields marked with the (linka.sensitive) option.
+func WithRedactor(r *redact.Redactor) Option {
+	return func(o *options) {
+		o.redactor = r
+	}
+}
+
+// WithPayloadMaxSize truncates the JSON encoded payloads longer than n bytes.
+func WithPayloadMaxSize(n int) Option {
+	return func(o *options) {
+		o.maxSize = n
+	}
+}
+
+// WithSampling logs only one in n calls. The failed and slow calls end are always logged.
+func WithSampling(n int) Option {
+	return func(o *options) {
+		o.sampling = n
+	}
+}
+
+// WithSlowThreshold logs the end of the calls lasting longer than d at least at the warn level.
+func WithSlowThreshold(d time.Duration) Option {
+	return func(o *options) {
+		o.slow = d
+	}
+}
+
+// LevelDecider returns the level of the call log lines. The code is codes.OK until the call ends.
+type LevelDecider func(fullMethod string, code codes.Code) logging.Level
+
+// WithLevelDecider sets the function deciding the level of the calls log lines,
+// overriding the go-grpc-middleware levels.
+func WithLevelDecider(fn LevelDecider) Option {
+	return func(o *options) {
+		o.level = fn
+	}
+}
+
+type options struct {
+	opts      []logging.Option
+	requests  []string
+	responses []string
+	maxSize   int
+	sampling  int
+	slow      time.Duration
+	level     LevelDecider
+	redactor  *redact.Redactor
+}
@@ -5,10 +5,21 @@ import (
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/auth"
 )
 
 type ExemplarFromCtxFunc func(ctx context.Context) prometheus.Labels
 
+// PrincipalExemplar is an ExemplarFromCtxFunc labelling the exemplars with the subject of the auth principal.
+func PrincipalExemplar(ctx context.Context) prometheus.Labels {
+	p, ok := auth.PrincipalFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return prometheus.Labels{"subject": p.Subject}
+}
+
 type Option func(*options)
 
 func WithCounterOptons(opts ...grpc_prometheus.CounterOption) Option {
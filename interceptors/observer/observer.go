@@ -0,0 +1,287 @@
+package observer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/interceptors"
+)
+
+type Interceptors interface {
+	interceptors.Interceptors
+	prometheus.Collector
+}
+
+// NewInterceptors returns interceptors observing the streams: they record the messages count and size,
+// the interval between the messages, the streams lifetime and the active streams as prometheus metrics,
+// and optionally the messages as events of the current span, see WithSpanEvents.
+// They must run after the tracing interceptors to record the span events.
+// The unary calls are not observed.
+func NewInterceptors(opts ...Option) Interceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	ob := &observer{o: o, server: newMetrics("server", o), client: newMetrics("client", o)}
+	o.reg.MustRegister(ob)
+	return ob
+}
+
+type observer struct {
+	o      options
+	server *metrics
+	client *metrics
+}
+
+type metrics struct {
+	messages *prometheus.CounterVec
+	bytes    *prometheus.CounterVec
+	interval *prometheus.HistogramVec
+	lifetime *prometheus.HistogramVec
+	active   *prometheus.GaugeVec
+}
+
+func newMetrics(side string, o options) *metrics {
+	name := func(n string) string {
+		return "grpc_" + side + "_stream_" + n
+	}
+	return &metrics{
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: name("messages_total"),
+			Help: "Total number of stream messages by direction.",
+		}, []string{"grpc_service", "grpc_method", "direction"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: name("bytes_total"),
+			Help: "Total size of the stream messages by direction.",
+		}, []string{"grpc_service", "grpc_method", "direction"}),
+		interval: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    name("message_interval_seconds"),
+			Help:    "Time between the consecutive messages of a stream by direction.",
+			Buckets: o.intervalBuckets,
+		}, []string{"grpc_service", "grpc_method", "direction"}),
+		lifetime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    name("lifetime_seconds"),
+			Help:    "Lifetime of the streams.",
+			Buckets: o.lifetimeBuckets,
+		}, []string{"grpc_service", "grpc_method", "grpc_code"}),
+		active: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: name("active"),
+			Help: "Number of active streams.",
+		}, []string{"grpc_service", "grpc_method"}),
+	}
+}
+
+func (m *metrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{m.messages, m.bytes, m.interval, m.lifetime, m.active}
+}
+
+func (ob *observer) Describe(c chan<- *prometheus.Desc) {
+	for _, v := range append(ob.server.collectors(), ob.client.collectors()...) {
+		v.Describe(c)
+	}
+}
+
+func (ob *observer) Collect(c chan<- prometheus.Metric) {
+	for _, v := range append(ob.server.collectors(), ob.client.collectors()...) {
+		v.Collect(c)
+	}
+}
+
+func (ob *observer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	}
+}
+
+func (ob *observer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		s := ob.newStream(ss.Context(), ob.server, info.FullMethod)
+		err := handler(srv, &serverStream{ServerStream: ss, s: s})
+		s.finish(status.Code(err))
+		return err
+	}
+}
+
+func (ob *observer) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (ob *observer) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		w := &clientStream{ClientStream: cs, s: ob.newStream(ctx, ob.client, method), serverStreams: desc.ServerStreams}
+		// the streams abandoned without reading the final status end with their context,
+		// the ones being read end with the status returned by RecvMsg
+		w.stop = context.AfterFunc(cs.Context(), func() {
+			if w.receiving.Load() == 0 {
+				w.s.finish(status.FromContextError(cs.Context().Err()).Code())
+			}
+		})
+		return w, nil
+	}
+}
+
+const (
+	sent     = "sent"
+	received = "received"
+)
+
+type stream struct {
+	o       options
+	m       *metrics
+	span    trace.Span
+	service string
+	method  string
+	start   time.Time
+
+	mu    sync.Mutex
+	last  map[string]time.Time
+	count map[string]int
+	once  sync.Once
+}
+
+func (ob *observer) newStream(ctx context.Context, m *metrics, fullMethod string) *stream {
+	service, method := split(fullMethod)
+	m.active.WithLabelValues(service, method).Inc()
+	return &stream{
+		o:       ob.o,
+		m:       m,
+		span:    trace.SpanFromContext(ctx),
+		service: service,
+		method:  method,
+		start:   time.Now(),
+		last:    make(map[string]time.Time, 2),
+		count:   make(map[string]int, 2),
+	}
+}
+
+func (s *stream) message(direction string, msg any) {
+	now := time.Now()
+	s.mu.Lock()
+	last, ok := s.last[direction]
+	s.last[direction] = now
+	s.count[direction]++
+	id := s.count[direction]
+	s.mu.Unlock()
+
+	s.m.messages.WithLabelValues(s.service, s.method, direction).Inc()
+	var size int
+	if m, ok := msg.(proto.Message); ok {
+		size = proto.Size(m)
+		s.m.bytes.WithLabelValues(s.service, s.method, direction).Add(float64(size))
+	}
+	if ok {
+		s.m.interval.WithLabelValues(s.service, s.method, direction).Observe(now.Sub(last).Seconds())
+	}
+	if s.o.sampling <= 0 || !s.span.IsRecording() || (id-1)%s.o.sampling != 0 {
+		return
+	}
+	typ := "SENT"
+	if direction == received {
+		typ = "RECEIVED"
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.message.type", typ),
+		attribute.Int("rpc.message.id", id),
+		attribute.Int("rpc.message.uncompressed_size", size),
+	}
+	if ok {
+		attrs = append(attrs, attribute.Int64("rpc.message.interval_ms", now.Sub(last).Milliseconds()))
+	}
+	s.span.AddEvent("message", trace.WithTimestamp(now), trace.WithAttributes(attrs...))
+}
+
+func (s *stream) finish(code codes.Code) {
+	s.once.Do(func() {
+		s.m.active.WithLabelValues(s.service, s.method).Dec()
+		s.m.lifetime.WithLabelValues(s.service, s.method, code.String()).Observe(time.Since(s.start).Seconds())
+	})
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	s *stream
+}
+
+func (w *serverStream) SendMsg(m any) error {
+	if err := w.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	w.s.message(sent, m)
+	return nil
+}
+
+func (w *serverStream) RecvMsg(m any) error {
+	if err := w.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	w.s.message(received, m)
+	return nil
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	s             *stream
+	stop          func() bool
+	serverStreams bool
+	receiving     atomic.Int32
+}
+
+func (w *clientStream) SendMsg(m any) error {
+	if err := w.ClientStream.SendMsg(m); err != nil {
+		return err
+	}
+	w.s.message(sent, m)
+	return nil
+}
+
+func (w *clientStream) RecvMsg(m any) error {
+	w.receiving.Add(1)
+	defer w.receiving.Add(-1)
+	err := w.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		w.s.message(received, m)
+		// the single response of the client streams ends the stream
+		if !w.serverStreams {
+			w.stop()
+			w.s.finish(codes.OK)
+		} else if err := w.Context().Err(); err != nil {
+			// the stream ended while receiving, the context callback skipped it
+			w.s.finish(status.FromContextError(err).Code())
+		}
+	case errors.Is(err, io.EOF):
+		w.stop()
+		w.s.finish(codes.OK)
+	default:
+		w.stop()
+		w.s.finish(status.Code(err))
+	}
+	return err
+}
+
+func split(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
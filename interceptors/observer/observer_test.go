@@ -0,0 +1,227 @@
+package observer
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+// gather returns the sum of the metrics values, or samples count for the histograms, by name.
+func gather(t *testing.T, reg *prometheus.Registry) map[string]float64 {
+	m, err := reg.Gather()
+	require.NoError(t, err)
+	values := map[string]float64{}
+	for _, f := range m {
+		for _, v := range f.GetMetric() {
+			switch {
+			case v.GetCounter() != nil:
+				values[f.GetName()] += v.GetCounter().GetValue()
+			case v.GetGauge() != nil:
+				values[f.GetName()] += v.GetGauge().GetValue()
+			case v.GetHistogram() != nil:
+				values[f.GetName()] += float64(v.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return values
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []string
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) SendMsg(m any) error {
+	return nil
+}
+
+func (s *fakeServerStream) RecvMsg(m any) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), wrapperspb.String(s.msgs[0]))
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestServer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	rec := tracetest.NewSpanRecorder()
+	ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test").Start(context.Background(), "stream")
+	i := NewInterceptors(WithRegisterer(reg), WithSpanEvents(2)).StreamServerInterceptor()
+
+	ss := &fakeServerStream{ctx: ctx, msgs: []string{"a", "bb", "ccc"}}
+	err := i(nil, ss, &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Chat", IsClientStream: true, IsServerStream: true}, func(srv any, ss grpc.ServerStream) error {
+		for {
+			m := &wrapperspb.StringValue{}
+			if err := ss.RecvMsg(m); err != nil {
+				return errors.Abortedf("done")
+			}
+			require.NoError(t, ss.SendMsg(m))
+		}
+	})
+	require.Error(t, err)
+	span.End()
+
+	values := gather(t, reg)
+	assert.Equal(t, float64(6), values["grpc_server_stream_messages_total"])
+	// 2 * (3 + 4 + 5) bytes
+	assert.Equal(t, float64(24), values["grpc_server_stream_bytes_total"])
+	assert.Equal(t, float64(4), values["grpc_server_stream_message_interval_seconds"])
+	assert.Equal(t, float64(1), values["grpc_server_stream_lifetime_seconds"])
+	assert.Equal(t, float64(0), values["grpc_server_stream_active"])
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	// messages 1 and 3 of each direction
+	assert.Len(t, spans[0].Events(), 4)
+	assert.Equal(t, "message", spans[0].Events()[0].Name)
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	recv int
+}
+
+func (c *fakeClientStream) Context() context.Context {
+	return c.ctx
+}
+
+func (c *fakeClientStream) SendMsg(m any) error {
+	return nil
+}
+
+func (c *fakeClientStream) RecvMsg(m any) error {
+	if c.recv == 0 {
+		return io.EOF
+	}
+	c.recv--
+	return nil
+}
+
+func TestClient(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	i := NewInterceptors(WithRegisterer(reg)).StreamClientInterceptor()
+	lifetime := func() float64 {
+		return gather(t, reg)["grpc_client_stream_lifetime_seconds"]
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cs, err := i(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/pkg.Service/Watch", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{ctx: ctx, recv: 2}, nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, cs.SendMsg(wrapperspb.String("a")))
+	for range 2 {
+		require.NoError(t, cs.RecvMsg(&wrapperspb.StringValue{}))
+	}
+	assert.Equal(t, float64(1), gather(t, reg)["grpc_client_stream_active"])
+	assert.ErrorIs(t, cs.RecvMsg(&wrapperspb.StringValue{}), io.EOF)
+	assert.Equal(t, float64(1), lifetime())
+	cancel()
+
+	// abandoned stream
+	ctx, cancel = context.WithCancel(context.Background())
+	_, err = i(ctx, &grpc.StreamDesc{ServerStreams: true}, nil, "/pkg.Service/Abandoned", func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{ctx: ctx}, nil
+	})
+	require.NoError(t, err)
+	cancel()
+	assert.Eventually(t, func() bool {
+		return lifetime() == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClientStreaming(t *testing.T) {
+	desc := &grpc.StreamDesc{StreamName: "Upload", ClientStreams: true}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "pkg.Service",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    desc.StreamName,
+			ClientStreams: true,
+			Handler: func(srv any, ss grpc.ServerStream) error {
+				var n int
+				for {
+					if err := ss.RecvMsg(&wrapperspb.StringValue{}); err == io.EOF {
+						break
+					} else if err != nil {
+						return err
+					}
+					n++
+				}
+				return ss.SendMsg(wrapperspb.Int64(int64(n)))
+			},
+		}},
+	}, struct{}{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	reg := prometheus.NewRegistry()
+	cc, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(NewInterceptors(WithRegisterer(reg)).StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	defer cc.Close()
+
+	cs, err := cc.NewStream(context.Background(), desc, "/pkg.Service/Upload")
+	require.NoError(t, err)
+	for _, v := range []string{"a", "b"} {
+		require.NoError(t, cs.SendMsg(wrapperspb.String(v)))
+	}
+	require.NoError(t, cs.CloseSend())
+	res := &wrapperspb.Int64Value{}
+	require.NoError(t, cs.RecvMsg(res))
+	assert.Equal(t, int64(2), res.GetValue())
+
+	// the stream ends with the response, without reading the final status
+	m, err := reg.Gather()
+	require.NoError(t, err)
+	var lifetime, active bool
+	for _, f := range m {
+		for _, v := range f.GetMetric() {
+			switch f.GetName() {
+			case "grpc_client_stream_lifetime_seconds":
+				for _, l := range v.GetLabel() {
+					if l.GetName() == "grpc_code" {
+						assert.Equal(t, "OK", l.GetValue())
+					}
+				}
+				assert.Equal(t, uint64(1), v.GetHistogram().GetSampleCount())
+				lifetime = true
+			case "grpc_client_stream_active":
+				assert.Zero(t, v.GetGauge().GetValue())
+				active = true
+			}
+		}
+	}
+	assert.True(t, lifetime)
+	assert.True(t, active)
+}
@@ -0,0 +1,49 @@
+package observer
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Option func(*options)
+
+// WithRegisterer sets the registerer of the metrics, prometheus.DefaultRegisterer by default.
+func WithRegisterer(reg prometheus.Registerer) Option {
+	return func(o *options) {
+		o.reg = reg
+	}
+}
+
+// WithIntervalBuckets sets the buckets of the inter-message interval histograms.
+func WithIntervalBuckets(buckets ...float64) Option {
+	return func(o *options) {
+		o.intervalBuckets = buckets
+	}
+}
+
+// WithLifetimeBuckets sets the buckets of the stream lifetime histograms.
+func WithLifetimeBuckets(buckets ...float64) Option {
+	return func(o *options) {
+		o.lifetimeBuckets = buckets
+	}
+}
+
+// WithSpanEvents records the messages as events of the current span, one every sampling messages
+// of each stream and direction: 1 records all of them.
+func WithSpanEvents(sampling int) Option {
+	return func(o *options) {
+		o.sampling = sampling
+	}
+}
+
+type options struct {
+	reg             prometheus.Registerer
+	intervalBuckets []float64
+	lifetimeBuckets []float64
+	sampling        int
+}
+
+var defaultOptions = options{
+	reg:             prometheus.DefaultRegisterer,
+	intervalBuckets: []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 30, 60},
+	lifetimeBuckets: []float64{.1, 1, 10, 60, 300, 900, 1800, 3600, 4 * 3600, 12 * 3600, 24 * 3600},
+}
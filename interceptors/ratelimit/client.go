@@ -0,0 +1,181 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+)
+
+// NewClientInterceptors returns client interceptors limiting the outgoing calls rate
+// with token buckets: a global one, one per target and one per method.
+//
+// When the server fails a call with RetryInfo or QuotaFailure details, the method
+// is paused for the retry delay, or the default backoff if none is provided.
+func NewClientInterceptors(opts ...Option) interceptors.ClientInterceptors {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	c := &client{o: o}
+	if o.global != nil {
+		c.global = newLimiter(*o.global)
+	}
+	return c
+}
+
+type client struct {
+	o      options
+	global *rate.Limiter
+	// targets holds the targets limiters
+	targets sync.Map
+	// methods holds the methods limiters
+	methods sync.Map
+	// paused holds the time until which the target methods are paused
+	paused sync.Map
+}
+
+func (c *client) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		target := targetOf(cc)
+		if err := c.wait(ctx, target, method); err != nil {
+			return err
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		c.observe(target, method, err)
+		return err
+	}
+}
+
+func (c *client) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		target := targetOf(cc)
+		if err := c.wait(ctx, target, method); err != nil {
+			return nil, err
+		}
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		c.observe(target, method, err)
+		return cs, err
+	}
+}
+
+func (c *client) wait(ctx context.Context, target, method string) error {
+	now := time.Now()
+	var delay time.Duration
+	if v, ok := c.paused.Load(target + method); ok {
+		delay = v.(time.Time).Sub(now)
+		if delay <= 0 {
+			// the pause is over, unless it was extended in the meantime
+			c.paused.CompareAndDelete(target+method, v)
+		}
+	}
+	var rs []*rate.Reservation
+	cancel := func() {
+		for _, r := range rs {
+			r.CancelAt(now)
+		}
+	}
+	for _, l := range c.limiters(target, method) {
+		r := l.ReserveN(now, 1)
+		if !r.OK() {
+			cancel()
+			return errors.ResourceExhaustedf("rate limit exceeded for %s", method)
+		}
+		rs = append(rs, r)
+		delay = max(delay, r.DelayFrom(now))
+	}
+	if delay <= 0 {
+		return nil
+	}
+	if dl, ok := ctx.Deadline(); c.o.mode == FailFast || (ok && dl.Sub(now) < delay) {
+		cancel()
+		return errors.ResourceExhaustedd(fmt.Errorf("rate limit exceeded for %s", method), &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (c *client) limiters(target, method string) []*rate.Limiter {
+	var out []*rate.Limiter
+	if c.global != nil {
+		out = append(out, c.global)
+	}
+	if c.o.target != nil {
+		v, _ := c.targets.LoadOrStore(target, newLimiter(*c.o.target))
+		out = append(out, v.(*rate.Limiter))
+	}
+	if v, ok := c.methods.Load(method); ok {
+		if l, ok := v.(*rate.Limiter); ok {
+			out = append(out, l)
+		}
+		return out
+	}
+	for _, v := range c.o.methods {
+		if methods.MatchAny(v.methods, method) {
+			l, _ := c.methods.LoadOrStore(method, newLimiter(v.limit))
+			return append(out, l.(*rate.Limiter))
+		}
+	}
+	// remember that the method has no limit
+	c.methods.Store(method, false)
+	return out
+}
+
+// observe pauses the method if the server asked to slow down.
+func (c *client) observe(target, method string, err error) {
+	if err == nil {
+		return
+	}
+	if _, ok := status.FromError(err); !ok {
+		return
+	}
+	delay, _ := errors.RetryDelay(err)
+	_, quota := errors.Detail[*errdetails.QuotaFailure](err)
+	if delay == 0 && quota {
+		delay = c.o.backoff
+	}
+	if delay <= 0 {
+		return
+	}
+	c.paused.Store(target+method, time.Now().Add(delay))
+}
+
+func newLimiter(l Limit) *rate.Limiter {
+	if l.Burst == 0 {
+		l.Burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(l.Rate), l.Burst)
+}
+
+// setLimit updates the limiter with l, keeping its current tokens.
+func setLimit(r *rate.Limiter, l Limit) {
+	if l.Burst == 0 {
+		l.Burst = 1
+	}
+	r.SetLimit(rate.Limit(l.Rate))
+	r.SetBurst(l.Burst)
+}
+
+func targetOf(cc *grpc.ClientConn) string {
+	if cc == nil {
+		return ""
+	}
+	return cc.Target()
+}
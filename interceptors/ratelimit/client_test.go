@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func okInvoker(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	return nil
+}
+
+func TestClientFailFast(t *testing.T) {
+	ctx := context.Background()
+	i := NewClientInterceptors(
+		WithMode(FailFast),
+		WithMethodLimit(Limit{Rate: 1, Burst: 2}, "/test.Service/*"),
+	).UnaryClientInterceptor()
+
+	for n := 0; n < 2; n++ {
+		require.NoError(t, i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker))
+	}
+	err := i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker)
+	require.Error(t, err)
+	assert.True(t, errors.IsResourceExhausted(err))
+
+	// each method has its own bucket
+	require.NoError(t, i(ctx, "/test.Service/List", nil, nil, nil, okInvoker))
+	// methods without limit are not limited
+	for n := 0; n < 10; n++ {
+		require.NoError(t, i(ctx, "/other.Service/Get", nil, nil, nil, okInvoker))
+	}
+}
+
+func TestClientBlock(t *testing.T) {
+	ctx := context.Background()
+	i := NewClientInterceptors(WithLimit(Limit{Rate: 20})).UnaryClientInterceptor()
+
+	start := time.Now()
+	for n := 0; n < 3; n++ {
+		require.NoError(t, i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, i(context.Background(), "/test.Service/Get", nil, nil, nil, okInvoker))
+	err := i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker)
+	require.Error(t, err)
+	assert.True(t, errors.IsResourceExhausted(err))
+}
+
+func TestClientServerBackoff(t *testing.T) {
+	ctx := context.Background()
+	c := NewClientInterceptors(WithMode(FailFast), WithDefaultBackoff(time.Hour)).(*client)
+	i := c.UnaryClientInterceptor()
+
+	retry := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return errors.ResourceExhaustedd(fmt.Errorf("slow down"), &errdetails.RetryInfo{RetryDelay: durationpb.New(50 * time.Millisecond)})
+	}
+	require.Error(t, i(ctx, "/test.Service/Get", nil, nil, nil, retry))
+	err := i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker)
+	require.Error(t, err)
+	assert.True(t, errors.IsResourceExhausted(err))
+	require.NoError(t, i(ctx, "/test.Service/List", nil, nil, nil, okInvoker))
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, i(ctx, "/test.Service/Get", nil, nil, nil, okInvoker))
+	_, paused := c.paused.Load("/test.Service/Get")
+	assert.False(t, paused, "expired pauses should be removed")
+
+	quota := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return errors.ResourceExhaustedd(fmt.Errorf("quota"), &errdetails.QuotaFailure{})
+	}
+	require.Error(t, i(ctx, "/test.Service/List", nil, nil, nil, quota))
+	require.Error(t, i(ctx, "/test.Service/List", nil, nil, nil, okInvoker))
+}
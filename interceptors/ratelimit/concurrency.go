@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newAIMD(c ConcurrencyLimit) *aimd {
+	c = c.withDefaults()
+	return &aimd{c: c, limit: float64(c.Initial)}
+}
+
+func (c ConcurrencyLimit) withDefaults() ConcurrencyLimit {
+	if c.Initial <= 0 {
+		c.Initial = 20
+	}
+	if c.Min <= 0 {
+		c.Min = 1
+	}
+	if c.Max <= 0 {
+		c.Max = 1000
+	}
+	if c.Backoff <= 0 || c.Backoff >= 1 {
+		c.Backoff = 0.9
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 5 * time.Second
+	}
+	return c
+}
+
+type aimd struct {
+	c        ConcurrencyLimit
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+}
+
+// update sets the limiter configuration, keeping the current limit within its new bounds.
+func (a *aimd) update(c ConcurrencyLimit) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.c = c.withDefaults()
+	a.limit = min(float64(a.c.Max), max(float64(a.c.Min), a.limit))
+}
+
+// acquire returns a function to call with the call result once it is done,
+// or false if the limit is reached.
+func (a *aimd) acquire() (release func(err error), ok bool) {
+	return a.take(true)
+}
+
+// acquireStream is like acquire, but the streams latency is not taken into account
+// as they may be long-lived.
+func (a *aimd) acquireStream() (release func(err error), ok bool) {
+	return a.take(false)
+}
+
+func (a *aimd) take(timed bool) (release func(err error), ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.inflight >= int(a.limit) {
+		return nil, false
+	}
+	a.inflight++
+	start := time.Now()
+	return func(err error) {
+		var latency time.Duration
+		if timed {
+			latency = time.Since(start)
+		}
+		a.release(latency, err)
+	}, true
+}
+
+func (a *aimd) release(latency time.Duration, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inflight := a.inflight
+	a.inflight--
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded:
+		a.limit = max(float64(a.c.Min), a.limit*a.c.Backoff)
+		return
+	}
+	if latency > a.c.Timeout {
+		a.limit = max(float64(a.c.Min), a.limit*a.c.Backoff)
+		return
+	}
+	// only grow when the limit is actually used
+	if inflight*2 >= int(a.limit) {
+		a.limit = min(float64(a.c.Max), a.limit+1)
+	}
+}
+
+func (a *aimd) current() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int(a.limit)
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"time"
+
+	"go.linka.cloud/grpc-toolkit/interceptors/ban"
+)
+
+// DefaultBackoff is the time the client waits before calling a method again after a QuotaFailure
+// sent by the server without RetryInfo.
+const DefaultBackoff = time.Second
+
+// Limit is a token bucket limit.
+type Limit struct {
+	// Rate is the number of calls allowed per second.
+	Rate float64
+	// Burst is the number of calls allowed at once. It defaults to 1.
+	Burst int
+}
+
+// Mode is the behaviour of the client interceptors when a call exceeds the limits.
+type Mode int
+
+const (
+	// Block waits until the call is allowed, or fails with codes.ResourceExhausted
+	// if the call deadline would be exceeded.
+	Block Mode = iota
+	// FailFast fails immediately with codes.ResourceExhausted.
+	FailFast
+)
+
+// ConcurrencyLimit configures an adaptive concurrency limiter using an AIMD algorithm:
+// the limit is increased by one when the in-flight calls get close to it and multiplied by Backoff
+// when a call is dropped, i.e. fails with codes.ResourceExhausted, codes.Unavailable or
+// codes.DeadlineExceeded, or takes longer than Timeout.
+type ConcurrencyLimit struct {
+	// Initial is the initial limit. It defaults to 20.
+	Initial int
+	// Min is the minimum limit. It defaults to 1.
+	Min int
+	// Max is the maximum limit. It defaults to 1000.
+	Max int
+	// Backoff is the ratio applied to the limit when a call is dropped. It defaults to 0.9.
+	Backoff float64
+	// Timeout is the latency above which a call is considered as dropped. It defaults to 5s.
+	Timeout time.Duration
+}
+
+var defaultOptions = options{
+	mode:      Block,
+	backoff:   DefaultBackoff,
+	actorFunc: ban.DefaultActorFunc,
+}
+
+type Option func(*options)
+
+// WithLimit sets the global limit shared by all the calls.
+func WithLimit(l Limit) Option {
+	return func(o *options) {
+		o.global = &l
+	}
+}
+
+// WithMethodLimit sets the limit of each of the given methods.
+// It takes a list of fully qualified method names, e.g. /helloworld.Greeter/SayHello,
+// or service wildcards, e.g. /helloworld.Greeter/*, in which case each matching method has its own limit.
+func WithMethodLimit(l Limit, methods ...string) Option {
+	return func(o *options) {
+		o.methods = append(o.methods, methodLimit{limit: l, methods: methods})
+	}
+}
+
+// WithActorLimit sets the limit of each actor, identified by the actor func.
+// It only applies to the server interceptors.
+func WithActorLimit(l Limit) Option {
+	return func(o *options) {
+		o.actor = &l
+	}
+}
+
+// WithActorFunc sets the function identifying the actors, as in the ban interceptors.
+// It defaults to ban.DefaultActorFunc. It only applies to the server interceptors.
+func WithActorFunc(f ban.ActorFunc) Option {
+	return func(o *options) {
+		o.actorFunc = f
+	}
+}
+
+// WithConcurrencyLimit enables adaptive concurrency limiting of the calls.
+// If no methods are given, the limiter is shared by all the calls, otherwise each matching method
+// has its own limiter. The streams count as in-flight calls until they end, but as they may be long-lived,
+// their latency is not compared to the Timeout. It only applies to the server interceptors.
+func WithConcurrencyLimit(c ConcurrencyLimit, methods ...string) Option {
+	return func(o *options) {
+		o.concurrency = append(o.concurrency, methodConcurrency{limit: c, methods: methods})
+	}
+}
+
+// WithTargetLimit sets the limit of each target the client connects to.
+// It only applies to the client interceptors.
+func WithTargetLimit(l Limit) Option {
+	return func(o *options) {
+		o.target = &l
+	}
+}
+
+// WithMode sets the behaviour of the client interceptors when a call exceeds the limits.
+// It defaults to Block. The server interceptors always fail fast.
+func WithMode(m Mode) Option {
+	return func(o *options) {
+		o.mode = m
+	}
+}
+
+// WithDefaultBackoff sets the time to wait before calling a method again after a QuotaFailure
+// sent by the server without RetryInfo. It defaults to DefaultBackoff.
+// The server interceptors send it as RetryInfo when the concurrency limit is reached.
+func WithDefaultBackoff(d time.Duration) Option {
+	return func(o *options) {
+		o.backoff = d
+	}
+}
+
+type methodLimit struct {
+	limit   Limit
+	methods []string
+}
+
+type methodConcurrency struct {
+	limit   ConcurrencyLimit
+	methods []string
+}
+
+type options struct {
+	global      *Limit
+	target      *Limit
+	actor       *Limit
+	actorFunc   ban.ActorFunc
+	methods     []methodLimit
+	concurrency []methodConcurrency
+	mode        Mode
+	backoff     time.Duration
+}
@@ -0,0 +1,319 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"go.linka.cloud/grpc-toolkit/config"
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+	"go.linka.cloud/grpc-toolkit/logger"
+)
+
+type ServerInterceptors interface {
+	interceptors.ServerInterceptors
+	// Update replaces the limits. The existing buckets and concurrency limiters are kept
+	// with their new limits, so that a reload does not let all the clients burst again.
+	Update(opts ...Option)
+	// Watch updates the limits each time the configuration changes, until the context is canceled.
+	// parse builds the options from the configuration content.
+	Watch(ctx context.Context, conf config.Config, parse func([]byte) ([]Option, error)) error
+}
+
+// NewServerInterceptors returns server interceptors limiting the incoming calls with token buckets:
+// a global one, one per actor and one per method, and with adaptive concurrency limiters.
+//
+// The calls exceeding the limits fail with codes.ResourceExhausted, along with RetryInfo
+// and QuotaFailure details.
+func NewServerInterceptors(opts ...Option) ServerInterceptors {
+	s := &server{}
+	s.Update(opts...)
+	return s
+}
+
+type server struct {
+	state atomic.Pointer[serverState]
+}
+
+type serverState struct {
+	o      options
+	global *rate.Limiter
+	// actors holds the actors limiters
+	actors *actors
+	// methods holds the methods limiters
+	methods sync.Map
+	// concurrency holds the methods concurrency limiters
+	concurrency sync.Map
+	// shared is the concurrency limiter shared by all the calls
+	shared *aimd
+}
+
+func (s *server) Update(opts ...Option) {
+	o := defaultOptions
+	for _, v := range opts {
+		v(&o)
+	}
+	st := &serverState{o: o, actors: &actors{}}
+	old := s.state.Load()
+	if o.global != nil {
+		st.global = newLimiter(*o.global)
+		if old != nil && old.global != nil {
+			st.global = old.global
+			setLimit(st.global, *o.global)
+		}
+	}
+	if o.actor != nil && old != nil && old.o.actor != nil {
+		st.actors = old.actors
+		if *o.actor != *old.o.actor {
+			st.actors.update(*o.actor)
+		}
+	}
+	for _, v := range o.concurrency {
+		if len(v.methods) != 0 {
+			continue
+		}
+		st.shared = newAIMD(v.limit)
+		if old != nil && old.shared != nil {
+			st.shared = old.shared
+			st.shared.update(v.limit)
+		}
+	}
+	if old != nil {
+		old.methods.Range(func(k, v any) bool {
+			if l, ok := v.(*rate.Limiter); ok {
+				if lim, ok := st.methodLimit(k.(string)); ok {
+					setLimit(l, lim)
+					st.methods.Store(k, l)
+				}
+			}
+			return true
+		})
+		old.concurrency.Range(func(k, v any) bool {
+			if a, ok := v.(*aimd); ok && a != old.shared {
+				if c, ok := st.methodConcurrency(k.(string)); ok {
+					a.update(c)
+					st.concurrency.Store(k, a)
+				}
+			}
+			return true
+		})
+	}
+	s.state.Store(st)
+}
+
+func (s *server) Watch(ctx context.Context, conf config.Config, parse func([]byte) ([]Option, error)) error {
+	b, err := conf.Read()
+	if err != nil {
+		return err
+	}
+	opts, err := parse(b)
+	if err != nil {
+		return err
+	}
+	s.Update(opts...)
+	updates := make(chan []byte)
+	if err := conf.Watch(ctx, updates); err != nil {
+		return err
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case b := <-updates:
+				opts, err := parse(b)
+				if err != nil {
+					logger.C(ctx).WithError(err).Error("failed to parse rate limits")
+					continue
+				}
+				s.Update(opts...)
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *server) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		st := s.state.Load()
+		if err := st.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		l := st.concurrencyLimiter(info.FullMethod)
+		if l == nil {
+			return handler(ctx, req)
+		}
+		release, ok := l.acquire()
+		if !ok {
+			return nil, st.concurrencyExhausted(info.FullMethod)
+		}
+		res, err := handler(ctx, req)
+		release(err)
+		return res, err
+	}
+}
+
+func (s *server) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		st := s.state.Load()
+		if err := st.allow(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		l := st.concurrencyLimiter(info.FullMethod)
+		if l == nil {
+			return handler(srv, ss)
+		}
+		release, ok := l.acquireStream()
+		if !ok {
+			return st.concurrencyExhausted(info.FullMethod)
+		}
+		err := handler(srv, ss)
+		release(err)
+		return err
+	}
+}
+
+func (s *serverState) allow(ctx context.Context, method string) error {
+	type bucket struct {
+		l       *rate.Limiter
+		subject string
+	}
+	var bs []bucket
+	if s.global != nil {
+		bs = append(bs, bucket{l: s.global, subject: "global"})
+	}
+	if s.o.actor != nil {
+		actor, ok, err := s.o.actorFunc(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			bs = append(bs, bucket{l: s.actors.get(actor, *s.o.actor), subject: "actor:" + actor})
+		}
+	}
+	if l := s.methodLimiter(method); l != nil {
+		bs = append(bs, bucket{l: l, subject: "method:" + method})
+	}
+	now := time.Now()
+	var rs []*rate.Reservation
+	for _, b := range bs {
+		r := b.l.ReserveN(now, 1)
+		if r.OK() && r.DelayFrom(now) == 0 {
+			rs = append(rs, r)
+			continue
+		}
+		delay := s.o.backoff
+		if r.OK() {
+			delay = r.DelayFrom(now)
+			r.CancelAt(now)
+		}
+		for _, v := range rs {
+			v.CancelAt(now)
+		}
+		return s.exhausted(fmt.Sprintf("rate limit exceeded for %s", method), delay, b.subject)
+	}
+	return nil
+}
+
+func (s *serverState) methodLimiter(method string) *rate.Limiter {
+	if v, ok := s.methods.Load(method); ok {
+		l, _ := v.(*rate.Limiter)
+		return l
+	}
+	if lim, ok := s.methodLimit(method); ok {
+		l, _ := s.methods.LoadOrStore(method, newLimiter(lim))
+		return l.(*rate.Limiter)
+	}
+	// remember that the method has no limit
+	s.methods.Store(method, false)
+	return nil
+}
+
+func (s *serverState) methodLimit(method string) (Limit, bool) {
+	for _, v := range s.o.methods {
+		if methods.MatchAny(v.methods, method) {
+			return v.limit, true
+		}
+	}
+	return Limit{}, false
+}
+
+func (s *serverState) concurrencyLimiter(method string) *aimd {
+	if v, ok := s.concurrency.Load(method); ok {
+		l, _ := v.(*aimd)
+		return l
+	}
+	if c, ok := s.methodConcurrency(method); ok {
+		l, _ := s.concurrency.LoadOrStore(method, newAIMD(c))
+		return l.(*aimd)
+	}
+	if s.shared == nil {
+		// remember that the method has no limit
+		s.concurrency.Store(method, false)
+		return nil
+	}
+	s.concurrency.Store(method, s.shared)
+	return s.shared
+}
+
+func (s *serverState) methodConcurrency(method string) (ConcurrencyLimit, bool) {
+	for _, v := range s.o.concurrency {
+		if len(v.methods) != 0 && methods.MatchAny(v.methods, method) {
+			return v.limit, true
+		}
+	}
+	return ConcurrencyLimit{}, false
+}
+
+func (s *serverState) concurrencyExhausted(method string) error {
+	return s.exhausted(fmt.Sprintf("concurrency limit reached for %s", method), s.o.backoff, "concurrency:"+method)
+}
+
+func (s *serverState) exhausted(msg string, delay time.Duration, subject string) error {
+	return errors.Build(codes.ResourceExhausted, msg).RetryInfo(delay).QuotaViolation(subject, msg).Err()
+}
+
+// actorsSweepInterval is the interval between the removals of the idle actors limiters.
+const actorsSweepInterval = time.Minute
+
+// actors holds the actors limiters. The limiters whose bucket is full are periodically removed:
+// they are the same as new ones, so that actors rotating their identity, e.g. their source address,
+// do not grow the memory without bound.
+type actors struct {
+	m sync.Map
+	// next is the time of the next sweep in unix nanoseconds
+	next atomic.Int64
+}
+
+func (a *actors) get(actor string, l Limit) *rate.Limiter {
+	now := time.Now()
+	if n := a.next.Load(); now.UnixNano() >= n && a.next.CompareAndSwap(n, now.Add(actorsSweepInterval).UnixNano()) {
+		go a.sweep(now)
+	}
+	v, _ := a.m.LoadOrStore(actor, newLimiter(l))
+	return v.(*rate.Limiter)
+}
+
+func (a *actors) sweep(now time.Time) {
+	a.m.Range(func(k, v any) bool {
+		if l := v.(*rate.Limiter); l.TokensAt(now) >= float64(l.Burst()) {
+			a.m.CompareAndDelete(k, v)
+		}
+		return true
+	})
+}
+
+func (a *actors) update(l Limit) {
+	a.m.Range(func(_, v any) bool {
+		setLimit(v.(*rate.Limiter), l)
+		return true
+	})
+}
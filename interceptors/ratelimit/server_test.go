@@ -0,0 +1,223 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func okHandler(ctx context.Context, req any) (any, error) {
+	return "ok", nil
+}
+
+func peerCtx(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 4242}})
+}
+
+func info(method string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{FullMethod: method}
+}
+
+func subject(t *testing.T, err error) string {
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, v := range s.Details() {
+		if q, ok := v.(*errdetails.QuotaFailure); ok {
+			require.Len(t, q.Violations, 1)
+			return q.Violations[0].Subject
+		}
+	}
+	t.Fatal("missing QuotaFailure")
+	return ""
+}
+
+func TestServerActorLimit(t *testing.T) {
+	i := NewServerInterceptors(WithActorLimit(Limit{Rate: 1, Burst: 2})).UnaryServerInterceptor()
+
+	a, b := peerCtx("10.0.0.1"), peerCtx("10.0.0.2")
+	for n := 0; n < 2; n++ {
+		_, err := i(a, nil, info("/test.Service/Get"), okHandler)
+		require.NoError(t, err)
+	}
+	_, err := i(a, nil, info("/test.Service/Get"), okHandler)
+	require.Error(t, err)
+	assert.True(t, errors.IsResourceExhausted(err))
+	assert.Equal(t, "actor:10.0.0.1", subject(t, err))
+
+	_, err = i(b, nil, info("/test.Service/Get"), okHandler)
+	require.NoError(t, err)
+	// calls without actor are not limited by actor
+	for n := 0; n < 5; n++ {
+		_, err = i(context.Background(), nil, info("/test.Service/Get"), okHandler)
+		require.NoError(t, err)
+	}
+}
+
+func TestServerMethodAndGlobalLimit(t *testing.T) {
+	s := NewServerInterceptors(
+		WithLimit(Limit{Rate: 1, Burst: 3}),
+		WithMethodLimit(Limit{Rate: 1, Burst: 1}, "/test.Service/Get"),
+	)
+	i := s.UnaryServerInterceptor()
+	ctx := context.Background()
+
+	_, err := i(ctx, nil, info("/test.Service/Get"), okHandler)
+	require.NoError(t, err)
+	_, err = i(ctx, nil, info("/test.Service/Get"), okHandler)
+	require.Error(t, err)
+	assert.Equal(t, "method:/test.Service/Get", subject(t, err))
+
+	// the rejected call did not consume the global bucket
+	for n := 0; n < 2; n++ {
+		_, err = i(ctx, nil, info("/test.Service/List"), okHandler)
+		require.NoError(t, err)
+	}
+	_, err = i(ctx, nil, info("/test.Service/List"), okHandler)
+	require.Error(t, err)
+	assert.Equal(t, "global", subject(t, err))
+
+	// the global limit is removed, the method bucket is kept
+	s.Update(WithMethodLimit(Limit{Rate: 1, Burst: 1}, "/test.Service/Get"))
+	_, err = i(ctx, nil, info("/test.Service/List"), okHandler)
+	require.NoError(t, err)
+	_, err = i(ctx, nil, info("/test.Service/Get"), okHandler)
+	require.Error(t, err)
+	assert.Equal(t, "method:/test.Service/Get", subject(t, err))
+}
+
+func TestServerConcurrencyLimit(t *testing.T) {
+	i := NewServerInterceptors(
+		WithConcurrencyLimit(ConcurrencyLimit{Initial: 1, Max: 1}, "/test.Service/Slow"),
+	).UnaryServerInterceptor()
+	ctx := context.Background()
+
+	started, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		_, _ = i(ctx, nil, info("/test.Service/Slow"), func(ctx context.Context, req any) (any, error) {
+			close(started)
+			<-done
+			return nil, nil
+		})
+	}()
+	<-started
+	_, err := i(ctx, nil, info("/test.Service/Slow"), okHandler)
+	require.Error(t, err)
+	assert.True(t, errors.IsResourceExhausted(err))
+	assert.Equal(t, "concurrency:/test.Service/Slow", subject(t, err))
+	// other methods are not limited
+	_, err = i(ctx, nil, info("/test.Service/Get"), okHandler)
+	require.NoError(t, err)
+	close(done)
+}
+
+func TestAIMD(t *testing.T) {
+	a := newAIMD(ConcurrencyLimit{Initial: 10, Min: 2, Max: 11, Timeout: time.Second})
+	var releases []func(error)
+	for n := 0; n < 10; n++ {
+		r, ok := a.acquire()
+		require.True(t, ok)
+		releases = append(releases, r)
+	}
+	_, ok := a.acquire()
+	assert.False(t, ok)
+
+	releases[0](nil)
+	assert.Equal(t, 11, a.current())
+	releases[1](nil)
+	assert.Equal(t, 11, a.current(), "limit should be capped to max")
+
+	releases[2](errors.Unavailablef("overloaded"))
+	assert.Equal(t, 9, a.current())
+	for _, r := range releases[3:] {
+		r(errors.ResourceExhaustedf("overloaded"))
+	}
+	for n := 0; n < 10; n++ {
+		r, ok := a.acquire()
+		require.True(t, ok)
+		r(errors.DeadlineExceededf("timeout"))
+	}
+	assert.Equal(t, 2, a.current(), "limit should not go below min")
+}
+
+func TestServerStreamConcurrencyLimit(t *testing.T) {
+	i := NewServerInterceptors(WithConcurrencyLimit(ConcurrencyLimit{Initial: 1, Max: 1, Timeout: time.Millisecond})).StreamServerInterceptor()
+	ss := &serverStream{ctx: context.Background()}
+	sinfo := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"}
+
+	started, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = i(nil, ss, sinfo, func(srv any, ss grpc.ServerStream) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+	err := i(nil, ss, sinfo, func(srv any, ss grpc.ServerStream) error { return nil })
+	require.Error(t, err)
+	assert.Equal(t, "concurrency:/test.Service/Watch", subject(t, err))
+	close(done)
+	// the stream lifetime is not counted as a drop
+	assert.Eventually(t, func() bool {
+		return i(nil, ss, sinfo, func(srv any, ss grpc.ServerStream) error { return nil }) == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestServerUpdateKeepsBuckets(t *testing.T) {
+	s := NewServerInterceptors(WithActorLimit(Limit{Rate: 0.001, Burst: 3}), WithMethodLimit(Limit{Rate: 0.001, Burst: 1}, "/test.Service/Get"))
+	i := s.UnaryServerInterceptor()
+	a := peerCtx("10.0.0.1")
+	_, err := i(a, nil, info("/test.Service/Get"), okHandler)
+	require.NoError(t, err)
+	_, err = i(a, nil, info("/test.Service/List"), okHandler)
+	require.NoError(t, err)
+
+	s.Update(WithActorLimit(Limit{Rate: 0.001, Burst: 4}), WithMethodLimit(Limit{Rate: 0.001, Burst: 1}, "/test.Service/Get"))
+	_, err = i(a, nil, info("/test.Service/Get"), okHandler)
+	require.Error(t, err)
+	assert.Equal(t, "method:/test.Service/Get", subject(t, err))
+	// the actor bucket kept its remaining token
+	_, err = i(a, nil, info("/test.Service/List"), okHandler)
+	require.NoError(t, err)
+	_, err = i(a, nil, info("/test.Service/List"), okHandler)
+	require.Error(t, err)
+	assert.Equal(t, "actor:10.0.0.1", subject(t, err))
+}
+
+func TestActorsSweep(t *testing.T) {
+	a := &actors{}
+	l := Limit{Rate: 1000, Burst: 1}
+	for n := range 100 {
+		a.get(fmt.Sprintf("10.0.0.%d", n), l).Allow()
+	}
+	busy := a.get("busy", Limit{Rate: 0.001, Burst: 1})
+	busy.Allow()
+	time.Sleep(10 * time.Millisecond)
+	a.sweep(time.Now())
+	var count int
+	a.m.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 1, count, "only the actors with tokens in use should be kept")
+}
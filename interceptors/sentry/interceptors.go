@@ -1,23 +1,46 @@
 package sentry
 
 import (
+	"context"
+
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	grpc_sentry "github.com/johnbellone/grpc-middleware-sentry"
 
 	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/redact"
 )
 
 type interceptor struct {
-	opts []grpc_sentry.Option
+	opts     []grpc_sentry.Option
+	redactor *redact.Redactor
 }
 
+// NewInterceptors returns interceptors reporting the errors and panics to sentry.
+// The request body attached to the server events is redacted from the fields marked
+// with the (linka.sensitive) option.
 func NewInterceptors(option ...grpc_sentry.Option) interceptors.Interceptors {
 	return &interceptor{opts: option}
 }
 
+// NewInterceptorsWithRedactor is like NewInterceptors, redacting the request body with the redactor.
+func NewInterceptorsWithRedactor(r *redact.Redactor, option ...grpc_sentry.Option) interceptors.Interceptors {
+	return &interceptor{opts: option, redactor: r}
+}
+
 func (i *interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
-	return grpc_sentry.UnaryServerInterceptor(i.opts...)
+	next := grpc_sentry.UnaryServerInterceptor(i.opts...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		m, ok := req.(proto.Message)
+		if !ok {
+			return next(ctx, req, info, handler)
+		}
+		// sentry only sees the redacted request, the handler still receives the original one
+		return next(ctx, i.redactor.Redact(m), info, func(ctx context.Context, _ any) (any, error) {
+			return handler(ctx, req)
+		})
+	}
 }
 
 func (i *interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
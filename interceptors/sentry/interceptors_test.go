@@ -0,0 +1,34 @@
+package sentry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+func TestRedactedRequestBody(t *testing.T) {
+	hub := sentry.NewHub(nil, sentry.NewScope())
+	ctx := sentry.SetHubOnContext(context.Background(), hub)
+	i := NewInterceptorsWithRedactor(redact.New(redact.WithPaths(&wrapperspb.StringValue{}, "value"))).UnaryServerInterceptor()
+
+	req := wrapperspb.String("secret")
+	_, err := i(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, r any) (any, error) {
+		assert.Same(t, req, r, "the handler should receive the original request")
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	ev := hub.Scope().ApplyToEvent(&sentry.Event{}, nil)
+	body, ok := ev.Extra["requestBody"].(proto.Message)
+	require.True(t, ok)
+	assert.True(t, proto.Equal(wrapperspb.String(redact.Placeholder), body))
+	assert.Equal(t, "secret", req.GetValue())
+}
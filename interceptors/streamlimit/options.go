@@ -0,0 +1,43 @@
+package streamlimit
+
+import (
+	"time"
+)
+
+type Option func(*options)
+
+// Limits bounds what the clients can send on a stream. The zero values disable the limits.
+type Limits struct {
+	// MaxMessages is the maximum number of messages received on a stream.
+	MaxMessages int
+	// MaxBytes is the maximum size of the messages received on a stream.
+	MaxBytes int64
+	// Rate is the maximum number of messages received per second.
+	Rate float64
+	// Burst is the number of messages that can be received at once above the rate, the rate rounded up by default.
+	Burst int
+	// IdleTimeout is the maximum time waiting for a message.
+	IdleTimeout time.Duration
+}
+
+// WithLimits sets the limits of the given methods, or of all methods if none is given.
+// It takes a list of fully qualified method names, e.g. /helloworld.Greeter/SayHello,
+// or service wildcards, e.g. /helloworld.Greeter/*.
+// The first matching limits apply.
+func WithLimits(l Limits, methods ...string) Option {
+	return func(o *options) {
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		o.limits = append(o.limits, methodLimits{limits: l, methods: methods})
+	}
+}
+
+type methodLimits struct {
+	limits  Limits
+	methods []string
+}
+
+type options struct {
+	limits []methodLimits
+}
@@ -0,0 +1,134 @@
+package streamlimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
+)
+
+// NewServerInterceptors returns server interceptors enforcing the limits of the messages received
+// on the client and bidi streams: the streams exceeding the messages count, size or rate limits
+// fail with codes.ResourceExhausted, and the ones idle for longer than the idle timeout
+// fail with codes.DeadlineExceeded. The unary calls are not limited.
+//
+// The messages size is their protobuf encoded size. When an idle timeout is set, the messages are received
+// in a separate goroutine: the handlers must return once RecvMsg failed.
+func NewServerInterceptors(opts ...Option) interceptors.ServerInterceptors {
+	o := options{}
+	for _, v := range opts {
+		v(&o)
+	}
+	return &limiter{o: o}
+}
+
+type limiter struct {
+	o options
+}
+
+func (l *limiter) limits(method string) (Limits, bool) {
+	for _, v := range l.o.limits {
+		if methods.MatchAny(v.methods, method) {
+			return v.limits, true
+		}
+	}
+	return Limits{}, false
+}
+
+func (l *limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	}
+}
+
+func (l *limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		lim, ok := l.limits(info.FullMethod)
+		if !ok || !info.IsClientStream {
+			return handler(srv, ss)
+		}
+		w := &stream{ServerStream: ss, limits: lim}
+		if lim.Rate > 0 {
+			burst := lim.Burst
+			if burst <= 0 {
+				burst = int(math.Ceil(lim.Rate))
+			}
+			w.rate = rate.NewLimiter(rate.Limit(lim.Rate), burst)
+		}
+		return handler(srv, w)
+	}
+}
+
+type stream struct {
+	grpc.ServerStream
+	limits Limits
+	rate   *rate.Limiter
+	count  int
+	bytes  int64
+	// err is the limit error, returned by all the subsequent receives
+	err error
+}
+
+func (s *stream) RecvMsg(m any) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.recv(m); err != nil {
+		return err
+	}
+	s.err = s.check(m)
+	return s.err
+}
+
+func (s *stream) check(m any) error {
+	s.count++
+	if s.limits.MaxMessages > 0 && s.count > s.limits.MaxMessages {
+		return errors.ResourceExhaustedf("stream exceeded the limit of %d messages", s.limits.MaxMessages)
+	}
+	if pm, ok := m.(proto.Message); ok {
+		s.bytes += int64(proto.Size(pm))
+	}
+	if s.limits.MaxBytes > 0 && s.bytes > s.limits.MaxBytes {
+		return errors.ResourceExhaustedf("stream exceeded the limit of %d bytes", s.limits.MaxBytes)
+	}
+	if s.rate != nil && !s.rate.Allow() {
+		return errors.ResourceExhaustedf("stream exceeded the rate of %g messages per second", s.limits.Rate)
+	}
+	return nil
+}
+
+func (s *stream) recv(m any) error {
+	if s.limits.IdleTimeout <= 0 {
+		return s.ServerStream.RecvMsg(m)
+	}
+	// receive in a separate message so that a late receive does not race with the handler
+	dst := m
+	pm, isProto := m.(proto.Message)
+	if isProto {
+		dst = pm.ProtoReflect().New().Interface()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.ServerStream.RecvMsg(dst)
+	}()
+	t := time.NewTimer(s.limits.IdleTimeout)
+	defer t.Stop()
+	select {
+	case err := <-done:
+		if err == nil && isProto {
+			proto.Reset(pm)
+			proto.Merge(pm, dst.(proto.Message))
+		}
+		return err
+	case <-t.C:
+		s.err = errors.DeadlineExceededf("stream idle for more than %v", s.limits.IdleTimeout)
+		return s.err
+	}
+}
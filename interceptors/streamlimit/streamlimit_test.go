@@ -0,0 +1,103 @@
+package streamlimit
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+type serverStream struct {
+	grpc.ServerStream
+	msgs chan string
+}
+
+func (s *serverStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	v, ok := <-s.msgs
+	if !ok {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), wrapperspb.String(v))
+	return nil
+}
+
+// recv runs the interceptor on a client stream, returning the received messages and the handler error.
+func recv(t *testing.T, l Limits, method string, msgs chan string) ([]string, error) {
+	i := NewServerInterceptors(WithLimits(Limits{MaxMessages: 1}, "/svc/Other"), WithLimits(l)).StreamServerInterceptor()
+	var out []string
+	err := i(nil, &serverStream{msgs: msgs}, &grpc.StreamServerInfo{FullMethod: method, IsClientStream: true}, func(srv any, ss grpc.ServerStream) error {
+		for {
+			m := &wrapperspb.StringValue{}
+			if err := ss.RecvMsg(m); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				// the following receives fail with the same error
+				assert.Equal(t, err, ss.RecvMsg(m))
+				return err
+			}
+			out = append(out, m.GetValue())
+		}
+	})
+	return out, err
+}
+
+func messages(n int, v string) chan string {
+	c := make(chan string, n)
+	for range n {
+		c <- v
+	}
+	close(c)
+	return c
+}
+
+func TestLimits(t *testing.T) {
+	out, err := recv(t, Limits{MaxMessages: 3}, "/svc/Method", messages(3, "a"))
+	require.NoError(t, err)
+	assert.Len(t, out, 3)
+
+	out, err = recv(t, Limits{MaxMessages: 3}, "/svc/Method", messages(4, "a"))
+	assert.True(t, errors.IsResourceExhausted(err))
+	assert.Len(t, out, 3)
+
+	_, err = recv(t, Limits{MaxMessages: 3}, "/svc/Other", messages(2, "a"))
+	assert.True(t, errors.IsResourceExhausted(err))
+
+	// each message is 6 bytes
+	out, err = recv(t, Limits{MaxBytes: 15}, "/svc/Method", messages(3, "abcd"))
+	assert.True(t, errors.IsResourceExhausted(err))
+	assert.Len(t, out, 2)
+
+	out, err = recv(t, Limits{Rate: 1, Burst: 2}, "/svc/Method", messages(3, "a"))
+	assert.True(t, errors.IsResourceExhausted(err))
+	assert.Len(t, out, 2)
+}
+
+func TestIdleTimeout(t *testing.T) {
+	msgs := make(chan string)
+	go func() {
+		msgs <- "a"
+		msgs <- "b"
+		time.Sleep(100 * time.Millisecond)
+		msgs <- "c"
+	}()
+	out, err := recv(t, Limits{IdleTimeout: 50 * time.Millisecond}, "/svc/Method", msgs)
+	assert.True(t, errors.IsDeadlineExceeded(err))
+	assert.Equal(t, []string{"a", "b"}, out)
+
+	out, err = recv(t, Limits{IdleTimeout: time.Second}, "/svc/Method", messages(2, "a"))
+	require.NoError(t, err)
+	assert.Len(t, out, 2)
+}
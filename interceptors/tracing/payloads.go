@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+// NewPayloadInterceptors returns interceptors recording the messages, redacted with the redactor,
+// as "payload" events of the current span. A nil redactor redacts the fields marked
+// with the (linka.sensitive) option.
+// They must run after the tracing interceptors.
+func NewPayloadInterceptors(r *redact.Redactor) interceptors.Interceptors {
+	return &payloads{r: r}
+}
+
+type payloads struct {
+	r *redact.Redactor
+}
+
+func (p *payloads) record(ctx context.Context, typ string, msg any) {
+	span := trace.SpanFromContext(ctx)
+	m, ok := msg.(proto.Message)
+	if !ok || !span.IsRecording() {
+		return
+	}
+	b, err := protojson.Marshal(p.r.Redact(m))
+	if err != nil {
+		return
+	}
+	span.AddEvent("payload", trace.WithAttributes(
+		attribute.String("rpc.message.type", typ),
+		attribute.String("rpc.message.content", string(b)),
+	))
+}
+
+func (p *payloads) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p.record(ctx, "RECEIVED", req)
+		res, err := handler(ctx, req)
+		if err == nil {
+			p.record(ctx, "SENT", res)
+		}
+		return res, err
+	}
+}
+
+func (p *payloads) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, p: p})
+	}
+}
+
+func (p *payloads) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		p.record(ctx, "SENT", req)
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		p.record(ctx, "RECEIVED", reply)
+		return nil
+	}
+}
+
+func (p *payloads) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, ctx: ctx, p: p}, nil
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	p *payloads
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.p.record(s.Context(), "SENT", m)
+	return nil
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.p.record(s.Context(), "RECEIVED", m)
+	return nil
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	// ctx holds the span, the stream context may not
+	ctx context.Context
+	p   *payloads
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	if err := s.ClientStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.p.record(s.ctx, "SENT", m)
+	return nil
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.p.record(s.ctx, "RECEIVED", m)
+	return nil
+}
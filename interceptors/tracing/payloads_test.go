@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.linka.cloud/grpc-toolkit/redact"
+)
+
+func TestPayloads(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test").Start(context.Background(), "call")
+	i := NewPayloadInterceptors(redact.New(redact.WithPaths(&wrapperspb.StringValue{}, "value"))).UnaryServerInterceptor()
+
+	_, err := i(ctx, wrapperspb.String("secret"), &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		return wrapperspb.Int64(42), nil
+	})
+	require.NoError(t, err)
+	span.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	content := func(i int) string {
+		for _, v := range events[i].Attributes {
+			if v.Key == "rpc.message.content" {
+				return v.Value.AsString()
+			}
+		}
+		return ""
+	}
+	assert.JSONEq(t, `"[REDACTED]"`, content(0))
+	assert.JSONEq(t, `"42"`, content(1))
+}
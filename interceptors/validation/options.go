@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"google.golang.org/protobuf/proto"
+)
+
+type Option func(*options)
+
+// WithResponses enables the validation of the responses: the server interceptors validate them
+// before sending, the client interceptors after receiving. Invalid responses fail with codes.Internal.
+func WithResponses() Option {
+	return func(o *options) {
+		o.responses = true
+	}
+}
+
+// WithSkipMethods disables the validation for the given methods. It takes a list of fully qualified method names,
+// e.g. /helloworld.Greeter/SayHello, or service wildcards, e.g. /helloworld.Greeter/*.
+func WithSkipMethods(methods ...string) Option {
+	return func(o *options) {
+		o.skip = append(o.skip, methods...)
+	}
+}
+
+// WithJSONNames makes the field violations paths use the fields proto JSON names, e.g. userName
+// instead of user_name, as seen by the gateway users.
+func WithJSONNames() Option {
+	return func(o *options) {
+		o.jsonNames = true
+	}
+}
+
+// ProtoValidator validates the messages with their buf.validate rules, like the buf.build/go/protovalidate Validator.
+// O is the validator options type, e.g. protovalidate.ValidationOption.
+type ProtoValidator[O any] interface {
+	Validate(m proto.Message, opts ...O) error
+}
+
+// WithProtovalidate validates all the messages with the buf.build/go/protovalidate validator after the
+// protoc-gen-validate rules, e.g.:
+//
+//	validation.WithProtovalidate(protovalidate.GlobalValidator)
+//
+// The violations of the protovalidate ValidationError are converted to BadRequest field violations.
+func WithProtovalidate[O any](v ProtoValidator[O]) Option {
+	return WithValidateFunc(func(m proto.Message) error {
+		return v.Validate(m)
+	})
+}
+
+// WithValidateFunc adds a validation function run on all the messages after the protoc-gen-validate rules.
+// The violations of the errors exposing them with a ToProto() method returning buf.validate.Violations,
+// like the protovalidate ValidationError, are converted to BadRequest details.
+func WithValidateFunc(fn func(m proto.Message) error) Option {
+	return func(o *options) {
+		o.validateFuncs = append(o.validateFuncs, fn)
+	}
+}
+
+type options struct {
+	responses     bool
+	skip          []string
+	jsonNames     bool
+	validateFuncs []func(m proto.Message) error
+}
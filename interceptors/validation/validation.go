@@ -5,9 +5,11 @@ import (
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	"go.linka.cloud/grpc-toolkit/errors"
 	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/internal/methods"
 )
 
 type validatorAll interface {
@@ -59,70 +61,126 @@ func validatorErrorToGrpc(e validatorError, prefix string) []*errdetails.BadRequ
 	}
 }
 
-func errToStatus(err error) error {
+// errToStatus converts the validation error of the message to an InvalidArgument status for the requests,
+// or to an Internal status for the responses, with the field violations as BadRequest details.
+func (i interceptor) errToStatus(m any, err error, response bool) error {
 	if err == nil {
 		return nil
 	}
+	var violations []*errdetails.BadRequest_FieldViolation
 	switch v := err.(type) {
 	case validatorError:
-		return errors.InvalidArgumentd(err, &errdetails.BadRequest{FieldViolations: validatorErrorToGrpc(v, "")})
+		violations = validatorErrorToGrpc(v, "")
 	case validatorMultiError:
-		details := &errdetails.BadRequest{}
 		for _, v := range v.AllErrors() {
 			if d, ok := v.(validatorError); ok {
-				details.FieldViolations = append(details.FieldViolations, validatorErrorToGrpc(d, "")...)
+				violations = append(violations, validatorErrorToGrpc(d, "")...)
 			}
 		}
-		return errors.InvalidArgumentd(err, details)
 	default:
-		return errors.InvalidArgument(err)
+		violations, _ = protoViolations(err)
 	}
+	if pm, ok := m.(proto.Message); ok && i.o.jsonNames {
+		for _, v := range violations {
+			v.Field = jsonPath(pm.ProtoReflect().Descriptor(), v.Field)
+		}
+	}
+	var details []proto.Message
+	if len(violations) != 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if response {
+		return errors.Internald(err, details...)
+	}
+	return errors.InvalidArgumentd(err, details...)
 }
 
-func (i interceptor) validate(req interface{}) error {
-	switch v := req.(type) {
+func (i interceptor) validate(m any, response bool) error {
+	var err error
+	switch v := m.(type) {
 	case validatorAll:
 		if i.all {
-			return errToStatus(v.ValidateAll())
+			err = v.ValidateAll()
+		} else {
+			err = v.Validate()
 		}
-		return errToStatus(v.Validate())
 	case validatorLegacy:
-		return errToStatus(v.Validate())
+		err = v.Validate()
 	case validator:
-		return errToStatus(v.Validate(i.all))
+		err = v.Validate(i.all)
 	}
-	return nil
+	if pm, ok := m.(proto.Message); ok && err == nil {
+		for _, fn := range i.o.validateFuncs {
+			if err = fn(pm); err != nil {
+				break
+			}
+		}
+	}
+	return i.errToStatus(m, err, response)
+}
+
+func (i interceptor) enabled(method string) bool {
+	return !methods.MatchAny(i.o.skip, method)
 }
 
 type interceptor struct {
 	all bool
+	o   options
 }
 
-func NewInterceptors(validateAll bool) interceptors.Interceptors {
-	return &interceptor{all: validateAll}
+// NewInterceptors returns interceptors validating the requests with the protoc-gen-validate generated methods,
+// and with the validators given with WithProtovalidate or WithValidateFunc.
+// If validateAll is true, all the violations are reported instead of the first one.
+func NewInterceptors(validateAll bool, opts ...Option) interceptors.Interceptors {
+	i := &interceptor{all: validateAll}
+	for _, v := range opts {
+		v(&i.o)
+	}
+	return i
 }
 
 // UnaryServerInterceptor returns a new unary server interceptor that validates incoming messages.
 //
 // Invalid messages will be rejected with `InvalidArgument` before reaching any userspace handlers.
+// Invalid responses will be replaced with an `Internal` error if the responses validation is enabled.
 func (i interceptor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if err := i.validate(req); err != nil {
+		if !i.enabled(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := i.validate(req, false); err != nil {
 			return nil, err
 		}
-		return handler(ctx, req)
+		res, err := handler(ctx, req)
+		if err != nil || !i.o.responses {
+			return res, err
+		}
+		if err := i.validate(res, true); err != nil {
+			return nil, err
+		}
+		return res, nil
 	}
 }
 
 // UnaryClientInterceptor returns a new unary client interceptor that validates outgoing messages.
 //
 // Invalid messages will be rejected with `InvalidArgument` before sending the request to server.
+// Invalid responses will fail with an `Internal` error if the responses validation is enabled.
 func (i interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		if err := i.validate(req); err != nil {
+		if !i.enabled(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if err := i.validate(req, false); err != nil {
 			return err
 		}
-		return invoker(ctx, method, req, reply, cc, opts...)
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		if i.o.responses {
+			return i.validate(reply, true)
+		}
+		return nil
 	}
 }
 
@@ -132,50 +190,64 @@ func (i interceptor) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 // type of the RPC. For `ServerStream` (1:m) requests, it will happen before reaching any userspace
 // handlers. For `ClientStream` (n:1) or `BidiStream` (n:m) RPCs, the messages will be rejected on
 // calls to `stream.Recv()`.
+// Invalid responses will be rejected on calls to `stream.Send()` if the responses validation is enabled.
 func (i interceptor) StreamServerInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		wrapper := &recvWrapper{ServerStream: stream, i: i}
-		return handler(srv, wrapper)
+		if !i.enabled(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		return handler(srv, &serverStream{ServerStream: stream, i: i})
 	}
 }
 
+// StreamClientInterceptor returns a new streaming client interceptor that validates outgoing messages
+// on calls to `stream.Send()`, and the received ones on calls to `stream.Recv()` if the responses validation is enabled.
 func (i interceptor) StreamClientInterceptor() grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
-		desc.Handler = (&sendWrapper{handler: desc.Handler, i: i}).Handler()
-		return streamer(ctx, desc, cc, method)
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || !i.enabled(method) {
+			return s, err
+		}
+		return &clientStream{ClientStream: s, i: i}, nil
 	}
 }
 
-type recvWrapper struct {
+type serverStream struct {
 	i interceptor
 	grpc.ServerStream
 }
 
-func (s *recvWrapper) RecvMsg(m interface{}) error {
+func (s *serverStream) RecvMsg(m interface{}) error {
 	if err := s.ServerStream.RecvMsg(m); err != nil {
 		return err
 	}
-	if err := s.i.validate(m); err != nil {
-		return err
+	return s.i.validate(m, false)
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	if s.i.o.responses {
+		if err := s.i.validate(m, true); err != nil {
+			return err
+		}
 	}
-	return nil
+	return s.ServerStream.SendMsg(m)
 }
 
-type sendWrapper struct {
+type clientStream struct {
 	i interceptor
-	grpc.ServerStream
-	handler grpc.StreamHandler
+	grpc.ClientStream
 }
 
-func (s *sendWrapper) Handler() grpc.StreamHandler {
-	return func(srv interface{}, stream grpc.ServerStream) error {
-		return s.handler(srv, s)
+func (s *clientStream) SendMsg(m interface{}) error {
+	if err := s.i.validate(m, false); err != nil {
+		return err
 	}
+	return s.ClientStream.SendMsg(m)
 }
 
-func (s *sendWrapper) SendMsg(m interface{}) error {
-	if err := s.i.validate(m); err != nil {
+func (s *clientStream) RecvMsg(m interface{}) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil || !s.i.o.responses {
 		return err
 	}
-	return s.ServerStream.SendMsg(m)
+	return s.i.validate(m, true)
 }
@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// violationsFile is a subset of the buf/validate/validate.proto violations messages.
+var violationsFile protoreflect.FileDescriptor
+
+func init() {
+	field := func(name string, n int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, label descriptorpb.FieldDescriptorProto_Label, oneof *int32) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{Name: proto.String(name), Number: proto.Int32(n), Type: typ.Enum(), Label: label.Enum(), OneofIndex: oneof}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	opt, rep := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL, descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	str, msg := descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	var err error
+	violationsFile, err = protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("interceptors/validation/validate_test.proto"),
+		Package: proto.String("buf.validate"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Violations"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("violations", 1, msg, ".buf.validate.Violation", rep, nil),
+			}},
+			{Name: proto.String("Violation"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("field", 5, msg, ".buf.validate.FieldPath", opt, nil),
+				field("rule_id", 2, str, "", opt, nil),
+				field("message", 3, str, "", opt, nil),
+			}},
+			{Name: proto.String("FieldPath"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("elements", 1, msg, ".buf.validate.FieldPathElement", rep, nil),
+			}},
+			{Name: proto.String("FieldPathElement"), Field: []*descriptorpb.FieldDescriptorProto{
+				field("field_name", 2, str, "", opt, nil),
+				field("index", 6, descriptorpb.FieldDescriptorProto_TYPE_UINT64, "", opt, proto.Int32(0)),
+				field("string_key", 10, str, "", opt, proto.Int32(0)),
+			}, OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("subscript")}}},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type element struct {
+	name  string
+	index *uint64
+	key   *string
+}
+
+// validationError mimics the protovalidate ValidationError.
+type validationError struct {
+	field []element
+	rule  string
+}
+
+func (e *validationError) Error() string {
+	return "validation error: " + e.rule
+}
+
+func (e *validationError) ToProto() proto.Message {
+	md := violationsFile.Messages()
+	path := dynamicpb.NewMessage(md.ByName("FieldPath"))
+	elements := path.Mutable(md.ByName("FieldPath").Fields().ByName("elements")).List()
+	for _, v := range e.field {
+		ed := md.ByName("FieldPathElement")
+		el := dynamicpb.NewMessage(ed)
+		el.Set(ed.Fields().ByName("field_name"), protoreflect.ValueOfString(v.name))
+		if v.index != nil {
+			el.Set(ed.Fields().ByName("index"), protoreflect.ValueOfUint64(*v.index))
+		}
+		if v.key != nil {
+			el.Set(ed.Fields().ByName("string_key"), protoreflect.ValueOfString(*v.key))
+		}
+		elements.Append(protoreflect.ValueOfMessage(el))
+	}
+	vd := md.ByName("Violation")
+	v := dynamicpb.NewMessage(vd)
+	v.Set(vd.Fields().ByName("field"), protoreflect.ValueOfMessage(path))
+	v.Set(vd.Fields().ByName("rule_id"), protoreflect.ValueOfString(e.rule))
+	out := dynamicpb.NewMessage(md.ByName("Violations"))
+	out.Mutable(md.ByName("Violations").Fields().ByName("violations")).List().Append(protoreflect.ValueOfMessage(v))
+	return out
+}
+
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	s := status.Convert(err)
+	for _, v := range s.Details() {
+		if d, ok := v.(*errdetails.BadRequest); ok {
+			return d.FieldViolations
+		}
+	}
+	require.Fail(t, "missing BadRequest details")
+	return nil
+}
+
+func TestValidateFunc(t *testing.T) {
+	index, key := uint64(1), "a.b"
+	verr := &validationError{rule: "string.min_len", field: []element{{name: "message_type", index: &index}, {name: "field"}, {name: "json_name"}}}
+	keyErr := &validationError{rule: "string.min_len", field: []element{{name: "nested_type", key: &key}, {name: "reserved_name"}}}
+	req := &descriptorpb.FileDescriptorProto{Name: proto.String("invalid")}
+	validate := func(m proto.Message) error {
+		if f, ok := m.(*descriptorpb.FileDescriptorProto); ok && f.GetName() == "invalid" {
+			return verr
+		}
+		if _, ok := m.(*descriptorpb.DescriptorProto); ok {
+			return keyErr
+		}
+		return nil
+	}
+	call := func(i grpc.UnaryServerInterceptor, method string, req proto.Message) error {
+		_, err := i(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			return &descriptorpb.DescriptorProto{}, nil
+		})
+		return err
+	}
+
+	i := NewInterceptors(true, WithValidateFunc(validate)).UnaryServerInterceptor()
+	err := call(i, "/svc/Method", req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "message_type[1].field.json_name", fieldViolations(t, err)[0].Field)
+	assert.Equal(t, "string.min_len", fieldViolations(t, err)[0].Description)
+	assert.NoError(t, call(i, "/svc/Method", &descriptorpb.FileDescriptorProto{}))
+
+	i = NewInterceptors(true, WithValidateFunc(validate), WithJSONNames(), WithResponses(), WithSkipMethods("/svc/Skip")).UnaryServerInterceptor()
+	err = call(i, "/svc/Method", req)
+	assert.Equal(t, "messageType[1].field.jsonName", fieldViolations(t, err)[0].Field)
+	err = call(i, "/svc/Method", &descriptorpb.FileDescriptorProto{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, `nestedType["a.b"].reservedName`, fieldViolations(t, err)[0].Field)
+	assert.NoError(t, call(i, "/svc/Skip", req))
+}
+
+type validationOption struct{}
+
+// protoValidator mimics the protovalidate Validator.
+type protoValidator interface {
+	Validate(m proto.Message, opts ...validationOption) error
+}
+
+type fieldValidator struct{}
+
+func (fieldValidator) Validate(m proto.Message, _ ...validationOption) error {
+	if f, ok := m.(*descriptorpb.FieldDescriptorProto); ok && f.GetName() == "" {
+		return &validationError{rule: "required", field: []element{{name: "name"}}}
+	}
+	return nil
+}
+
+func TestProtovalidate(t *testing.T) {
+	var v protoValidator = fieldValidator{}
+	i := NewInterceptors(false, WithProtovalidate(v)).UnaryServerInterceptor()
+	call := func(req proto.Message) error {
+		_, err := i(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		return err
+	}
+	err := call(&descriptorpb.FieldDescriptorProto{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	vs := fieldViolations(t, err)
+	require.Len(t, vs, 1)
+	assert.Equal(t, "name", vs[0].Field)
+	assert.Equal(t, "required", vs[0].Description)
+	assert.NoError(t, call(&descriptorpb.FieldDescriptorProto{Name: proto.String("name")}))
+}
@@ -0,0 +1,159 @@
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoViolations returns the field violations of the errors exposing a ToProto() method returning
+// a buf.validate.Violations message, like the buf.build/go/protovalidate ValidationError.
+// The message is read through reflection so that protovalidate is not a dependency.
+func protoViolations(err error) ([]*errdetails.BadRequest_FieldViolation, bool) {
+	for ; err != nil; err = unwrap(err) {
+		fn := reflect.ValueOf(err).MethodByName("ToProto")
+		if !fn.IsValid() || fn.Type().NumIn() != 0 || fn.Type().NumOut() != 1 {
+			continue
+		}
+		m, ok := fn.Call(nil)[0].Interface().(proto.Message)
+		if !ok || m.ProtoReflect().Descriptor().FullName() != "buf.validate.Violations" {
+			continue
+		}
+		var out []*errdetails.BadRequest_FieldViolation
+		l := get(m.ProtoReflect(), "violations").List()
+		for i := 0; i < l.Len(); i++ {
+			v := l.Get(i).Message()
+			d := str(v, "message")
+			if d == "" {
+				d = str(v, "rule_id")
+			}
+			// the field path is a string in the earlier protovalidate versions
+			f := str(v, "field_path")
+			if p := get(v, "field"); p.IsValid() {
+				f = fieldPath(p.Message())
+			}
+			out = append(out, &errdetails.BadRequest_FieldViolation{Field: f, Description: d})
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func unwrap(err error) error {
+	u, ok := err.(interface{ Unwrap() error })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
+
+// get returns the value of the field, or an invalid value if the message has no such field.
+func get(m protoreflect.Message, name protoreflect.Name) protoreflect.Value {
+	if !m.IsValid() {
+		return protoreflect.Value{}
+	}
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil {
+		return protoreflect.Value{}
+	}
+	return m.Get(fd)
+}
+
+func str(m protoreflect.Message, name protoreflect.Name) string {
+	v := get(m, name)
+	if !v.IsValid() {
+		return ""
+	}
+	return v.String()
+}
+
+// fieldPath formats a buf.validate.FieldPath, e.g. items[0].labels["key"].
+func fieldPath(m protoreflect.Message) string {
+	if !m.IsValid() {
+		return ""
+	}
+	var b strings.Builder
+	l := get(m, "elements").List()
+	for i := 0; i < l.Len(); i++ {
+		e := l.Get(i).Message()
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(str(e, "field_name"))
+		o := e.Descriptor().Oneofs().ByName("subscript")
+		if o == nil {
+			continue
+		}
+		fd := e.WhichOneof(o)
+		if fd == nil {
+			continue
+		}
+		b.WriteByte('[')
+		if fd.Kind() == protoreflect.StringKind {
+			b.WriteString(strconv.Quote(e.Get(fd).String()))
+		} else {
+			b.WriteString(e.Get(fd).String())
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// jsonPath converts the proto names of the path fields, e.g. user_names[0].first_name,
+// to their JSON names, e.g. userNames[0].firstName. The segments that cannot be resolved are kept as is.
+func jsonPath(md protoreflect.MessageDescriptor, path string) string {
+	var out []string
+	for _, s := range splitPath(path) {
+		if md == nil {
+			out = append(out, s)
+			continue
+		}
+		name, sub := s, ""
+		if i := strings.IndexByte(s, '['); i >= 0 {
+			name, sub = s[:i], s[i:]
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			out, md = append(out, s), nil
+			continue
+		}
+		out = append(out, fd.JSONName()+sub)
+		if fd.IsMap() {
+			md = fd.MapValue().Message()
+		} else {
+			md = fd.Message()
+		}
+	}
+	return strings.Join(out, ".")
+}
+
+// splitPath splits the path on the dots outside of the subscripts.
+func splitPath(path string) []string {
+	var (
+		out    []string
+		depth  int
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			out = append(out, path[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, path[start:])
+}
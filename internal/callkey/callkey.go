@@ -0,0 +1,46 @@
+package callkey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// Target returns the target of the client connection, or an empty string if it is nil.
+func Target(cc *grpc.ClientConn) string {
+	if cc == nil {
+		return ""
+	}
+	return cc.Target()
+}
+
+// Key returns a key identifying the call from the connection target, the method name, the deterministically
+// marshaled request and the outgoing metadata values of the given keys.
+// It returns false if the request is not a proto.Message.
+func Key(ctx context.Context, target, method string, req any, mdKeys ...string) (string, bool) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	h.Write([]byte(target))
+	h.Write([]byte{0})
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write(b)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for _, k := range mdKeys {
+		h.Write([]byte{0})
+		h.Write([]byte(strings.Join(md.Get(k), "\x00")))
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
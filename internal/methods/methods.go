@@ -0,0 +1,67 @@
+package methods
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var descriptors sync.Map
+
+// Descriptor returns the descriptor registered in the global registry for the fully qualified
+// method name, e.g. /helloworld.Greeter/SayHello.
+func Descriptor(fullMethod string) (protoreflect.MethodDescriptor, bool) {
+	if v, ok := descriptors.Load(fullMethod); ok {
+		md, ok := v.(protoreflect.MethodDescriptor)
+		return md, ok
+	}
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1))
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		// cache the miss too
+		descriptors.Store(fullMethod, nil)
+		return nil, false
+	}
+	md, ok := d.(protoreflect.MethodDescriptor)
+	descriptors.Store(fullMethod, md)
+	return md, ok
+}
+
+// Option returns the value of the method option extension xt for the fully qualified method name.
+// It returns false if the method is not registered or if the option is not set.
+func Option[T any](fullMethod string, xt protoreflect.ExtensionType) (T, bool) {
+	var zero T
+	md, ok := Descriptor(fullMethod)
+	if !ok || md.Options() == nil || !proto.HasExtension(md.Options(), xt) {
+		return zero, false
+	}
+	v, ok := proto.GetExtension(md.Options(), xt).(T)
+	return v, ok
+}
+
+// Match reports whether the fully qualified method name matches the pattern.
+// The pattern is either a fully qualified method name, e.g. /helloworld.Greeter/SayHello,
+// a service wildcard, e.g. /helloworld.Greeter/*, a package wildcard, e.g. /helloworld.*,
+// or * to match all the methods.
+func Match(pattern, fullMethod string) bool {
+	if pattern == "*" || pattern == fullMethod {
+		return true
+	}
+	if !strings.HasSuffix(pattern, "*") {
+		return false
+	}
+	return strings.HasPrefix(fullMethod, strings.TrimSuffix(pattern, "*"))
+}
+
+// MatchAny reports whether the fully qualified method name matches one of the patterns.
+func MatchAny(patterns []string, fullMethod string) bool {
+	for _, v := range patterns {
+		if Match(v, fullMethod) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+const signatureSize = 16
+
+// Tokens encodes and decodes opaque page tokens signed with its key, so that the clients cannot tamper with them.
+type Tokens struct {
+	key []byte
+}
+
+// NewTokens returns the page tokens codec signing with the given key. The key must be shared by all the instances
+// of a service, and kept secret. An empty key, which would allow to forge the tokens, returns an error.
+func NewTokens(key []byte) (*Tokens, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("pagination: empty tokens key")
+	}
+	return &Tokens{key: append([]byte(nil), key...)}, nil
+}
+
+// Offset returns the page token of the offset for the listings with the given parameters.
+func (t *Tokens) Offset(offset uint64, params ...string) string {
+	return t.Encode(&PageToken{Position: &PageToken_Offset{Offset: offset}, Params: Hash(params...)})
+}
+
+// Cursor returns the page token of the cursor for the listings with the given parameters.
+func (t *Tokens) Cursor(cursor []byte, params ...string) string {
+	return t.Encode(&PageToken{Position: &PageToken_Cursor{Cursor: cursor}, Params: Hash(params...)})
+}
+
+// Encode returns the signed page token.
+func (t *Tokens) Encode(tk *PageToken) string {
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(tk)
+	return base64.RawURLEncoding.EncodeToString(append(b, t.sign(b)...))
+}
+
+// Decode returns the content of the page token, checking its signature and that it was issued for
+// the listings with the given parameters. An empty token decodes to an empty PageToken, i.e. the first page.
+// Invalid tokens return codes.InvalidArgument errors.
+func (t *Tokens) Decode(token string, params ...string) (*PageToken, error) {
+	if token == "" {
+		return &PageToken{}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) < signatureSize {
+		return nil, errors.InvalidArgumentf("invalid page token")
+	}
+	b, sig := b[:len(b)-signatureSize], b[len(b)-signatureSize:]
+	if !hmac.Equal(sig, t.sign(b)) {
+		return nil, errors.InvalidArgumentf("invalid page token")
+	}
+	var tk PageToken
+	if err := proto.Unmarshal(b, &tk); err != nil {
+		return nil, errors.InvalidArgumentf("invalid page token")
+	}
+	if !hmac.Equal(tk.Params, Hash(params...)) {
+		return nil, errors.InvalidArgumentf("page token does not match the request parameters")
+	}
+	return &tk, nil
+}
+
+func (t *Tokens) sign(b []byte) []byte {
+	h := hmac.New(sha256.New, t.key)
+	h.Write(b)
+	return h.Sum(nil)[:signatureSize]
+}
+
+// Hash returns the hash of the listing parameters, e.g. the filter and the order.
+func Hash(params ...string) []byte {
+	h := sha256.New()
+	for _, v := range params {
+		// length prefixed so that ("ab", "c") and ("a", "bc") differ
+		h.Write([]byte{byte(len(v) >> 24), byte(len(v) >> 16), byte(len(v) >> 8), byte(len(v))})
+		h.Write([]byte(v))
+	}
+	return h.Sum(nil)[:16]
+}
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: pagination/pagination.proto
+
+package pagination
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PageToken is the content of the opaque page tokens.
+type PageToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Position:
+	//
+	//	*PageToken_Offset
+	//	*PageToken_Cursor
+	Position isPageToken_Position `protobuf_oneof:"position"`
+	// params is the hash of the listing parameters, e.g. the filter and the order: the tokens are only valid
+	// for the listings with the same parameters.
+	Params        []byte `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageToken) Reset() {
+	*x = PageToken{}
+	mi := &file_pagination_pagination_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageToken) ProtoMessage() {}
+
+func (x *PageToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pagination_pagination_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageToken.ProtoReflect.Descriptor instead.
+func (*PageToken) Descriptor() ([]byte, []int) {
+	return file_pagination_pagination_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageToken) GetPosition() isPageToken_Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *PageToken) GetOffset() uint64 {
+	if x != nil {
+		if x, ok := x.Position.(*PageToken_Offset); ok {
+			return x.Offset
+		}
+	}
+	return 0
+}
+
+func (x *PageToken) GetCursor() []byte {
+	if x != nil {
+		if x, ok := x.Position.(*PageToken_Cursor); ok {
+			return x.Cursor
+		}
+	}
+	return nil
+}
+
+func (x *PageToken) GetParams() []byte {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type isPageToken_Position interface {
+	isPageToken_Position()
+}
+
+type PageToken_Offset struct {
+	// offset is the number of items listed by the previous pages.
+	Offset uint64 `protobuf:"varint,1,opt,name=offset,proto3,oneof"`
+}
+
+type PageToken_Cursor struct {
+	// cursor is the position of the next page in the listed source, e.g. the key of its first item.
+	Cursor []byte `protobuf:"bytes,2,opt,name=cursor,proto3,oneof"`
+}
+
+func (*PageToken_Offset) isPageToken_Position() {}
+
+func (*PageToken_Cursor) isPageToken_Position() {}
+
+var File_pagination_pagination_proto protoreflect.FileDescriptor
+
+const file_pagination_pagination_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpagination/pagination.proto\x12\x05linka\"c\n" +
+	"\tPageToken\x12\x18\n" +
+	"\x06offset\x18\x01 \x01(\x04H\x00R\x06offset\x12\x18\n" +
+	"\x06cursor\x18\x02 \x01(\fH\x00R\x06cursor\x12\x16\n" +
+	"\x06params\x18\x03 \x01(\fR\x06paramsB\n" +
+	"\n" +
+	"\bpositionB(Z&go.linka.cloud/grpc-toolkit/paginationb\x06proto3"
+
+var (
+	file_pagination_pagination_proto_rawDescOnce sync.Once
+	file_pagination_pagination_proto_rawDescData []byte
+)
+
+func file_pagination_pagination_proto_rawDescGZIP() []byte {
+	file_pagination_pagination_proto_rawDescOnce.Do(func() {
+		file_pagination_pagination_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pagination_pagination_proto_rawDesc), len(file_pagination_pagination_proto_rawDesc)))
+	})
+	return file_pagination_pagination_proto_rawDescData
+}
+
+var file_pagination_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pagination_pagination_proto_goTypes = []any{
+	(*PageToken)(nil), // 0: linka.PageToken
+}
+var file_pagination_pagination_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pagination_pagination_proto_init() }
+func file_pagination_pagination_proto_init() {
+	if File_pagination_pagination_proto != nil {
+		return
+	}
+	file_pagination_pagination_proto_msgTypes[0].OneofWrappers = []any{
+		(*PageToken_Offset)(nil),
+		(*PageToken_Cursor)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pagination_pagination_proto_rawDesc), len(file_pagination_pagination_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pagination_pagination_proto_goTypes,
+		DependencyIndexes: file_pagination_pagination_proto_depIdxs,
+		MessageInfos:      file_pagination_pagination_proto_msgTypes,
+	}.Build()
+	File_pagination_pagination_proto = out.File
+	file_pagination_pagination_proto_goTypes = nil
+	file_pagination_pagination_proto_depIdxs = nil
+}
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func TestTokens(t *testing.T) {
+	_, err := NewTokens(nil)
+	assert.Error(t, err)
+	tokens, err := NewTokens([]byte("secret"))
+	require.NoError(t, err)
+
+	tk, err := tokens.Decode("", "filter")
+	require.NoError(t, err)
+	assert.Zero(t, tk.GetOffset())
+	assert.Nil(t, tk.GetCursor())
+
+	tk, err = tokens.Decode(tokens.Offset(42, "filter", "order"), "filter", "order")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), tk.GetOffset())
+
+	tk, err = tokens.Decode(tokens.Cursor([]byte("key"), "filter"), "filter")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), tk.GetCursor())
+
+	_, err = tokens.Decode(tokens.Offset(42, "filter"), "other")
+	assert.True(t, errors.IsInvalidArgument(err))
+	_, err = tokens.Decode(tokens.Offset(42, "ab", "c"), "a", "bc")
+	assert.True(t, errors.IsInvalidArgument(err))
+	other, err := NewTokens([]byte("other"))
+	require.NoError(t, err)
+	_, err = other.Decode(tokens.Offset(42, "filter"), "filter")
+	assert.True(t, errors.IsInvalidArgument(err))
+
+	b, err := base64.RawURLEncoding.DecodeString(tokens.Offset(42))
+	require.NoError(t, err)
+	b[1]++
+	_, err = tokens.Decode(base64.RawURLEncoding.EncodeToString(b))
+	assert.True(t, errors.IsInvalidArgument(err))
+	_, err = tokens.Decode("not a token")
+	assert.True(t, errors.IsInvalidArgument(err))
+}
+
+func TestBounds(t *testing.T) {
+	b := Bounds{Default: 10, Max: 100}
+	for size, want := range map[int32]int32{0: 10, 1: 1, 100: 100} {
+		got, err := b.Size(size)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	for _, size := range []int32{-1, 101} {
+		_, err := b.Size(size)
+		assert.True(t, errors.IsInvalidArgument(err))
+	}
+	got, err := Bounds{}.Size(0)
+	require.NoError(t, err)
+	assert.Equal(t, int32(DefaultPageSize), got)
+}
+
+func TestDefaults(t *testing.T) {
+	md := &descriptorpb.DescriptorProto{Name: proto.String("ListRequest"), Field: []*descriptorpb.FieldDescriptorProto{{
+		Name:   proto.String(PageSizeField),
+		Number: proto.Int32(1),
+		Type:   descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+	}}}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("pagination/pagination_test.proto"),
+		Package:     proto.String("pagination.test"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{md},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	req := dynamicpb.NewMessage(fd.Messages().ByName("ListRequest"))
+	size := req.Descriptor().Fields().ByName(PageSizeField)
+
+	Defaults(20)(req)
+	assert.Equal(t, int64(20), req.Get(size).Int())
+	Defaults(30)(req)
+	assert.Equal(t, int64(20), req.Get(size).Int())
+
+	// no page_size field
+	other := &descriptorpb.FileDescriptorProto{}
+	Defaults(20)(other)
+	assert.True(t, proto.Equal(&descriptorpb.FileDescriptorProto{}, other))
+	Defaults(20)((*descriptorpb.FileDescriptorProto)(nil))
+}
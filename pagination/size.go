@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// PageSizeField is the name of the page size field of the List requests.
+const PageSizeField = "page_size"
+
+// Bounds are the page sizes bounds of a listing.
+type Bounds struct {
+	// Default is the page size of the requests without page size, DefaultPageSize if zero.
+	Default int32
+	// Max is the maximum page size, MaxPageSize if zero.
+	Max int32
+}
+
+// Size returns the page size to use for the requested size: the default size if it is not set.
+// Negative sizes and sizes above the maximum return codes.InvalidArgument errors.
+func (b Bounds) Size(size int32) (int32, error) {
+	def, max := b.Default, b.Max
+	if def == 0 {
+		def = DefaultPageSize
+	}
+	if max == 0 {
+		max = MaxPageSize
+	}
+	switch {
+	case size == 0:
+		return min(def, max), nil
+	case size < 0:
+		return 0, errors.InvalidArgumentf("page_size must not be negative")
+	case size > max:
+		return 0, errors.InvalidArgumentf("page_size must not be greater than %d", max)
+	}
+	return size, nil
+}
+
+// Defaults returns a function setting the page_size field of the messages to size when it is not set,
+// to be used with the defaulter interceptors:
+//
+//	defaulter.NewInterceptors(defaulter.WithDefaults(pagination.Defaults(50)))
+//
+// The messages without an int32 page_size field are left untouched.
+func Defaults(size int32) func(m proto.Message) {
+	return func(m proto.Message) {
+		r := m.ProtoReflect()
+		fd := r.Descriptor().Fields().ByName(PageSizeField)
+		if !r.IsValid() || fd == nil || fd.Kind() != protoreflect.Int32Kind || fd.IsList() || r.Get(fd).Int() != 0 {
+			return
+		}
+		r.Set(fd, protoreflect.ValueOfInt32(size))
+	}
+}
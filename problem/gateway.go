@@ -0,0 +1,40 @@
+package problem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// ErrorHandler is a grpc-gateway runtime.ErrorHandlerFunc rendering the errors as problems.
+// Like the runtime.DefaultHTTPErrorHandler, it forwards the response headers metadata
+// and honors the runtime.HTTPStatusError status.
+func ErrorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var custom *runtime.HTTPStatusError
+	if errors.As(err, &custom) {
+		err = custom.Err
+	}
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for k, vs := range md.HeaderMD {
+			for _, v := range vs {
+				w.Header().Add(fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, textproto.CanonicalMIMEHeaderKey(k)), v)
+			}
+		}
+	}
+	p := FromError(err)
+	p.Instance = r.URL.Path
+	if custom != nil {
+		p.Status = custom.HTTPStatus
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", p.Detail)
+	}
+	p.Write(w)
+}
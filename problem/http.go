@@ -0,0 +1,81 @@
+package problem
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// maxDetail is the maximum size of the HTTP errors bodies kept as the problems details.
+const maxDetail = 1024
+
+// Handler wraps the handler so that its plain HTTP errors, e.g. written with http.Error, are rendered as problems,
+// the errors body becoming the problem detail. The gRPC responses and the responses with other content types
+// are left untouched.
+func Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		h.ServeHTTP(rw, r)
+		if rw.problem == nil {
+			return
+		}
+		rw.problem.Instance = r.URL.Path
+		rw.problem.Detail = strings.TrimSpace(rw.detail.String())
+		rw.problem.Write(w)
+	})
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	wrote   bool
+	problem *Problem
+	detail  bytes.Buffer
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if w.wrote {
+		return
+	}
+	w.wrote = true
+	ct := w.Header().Get("Content-Type")
+	if code < http.StatusBadRequest || (ct != "" && !strings.HasPrefix(ct, "text/plain")) {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.Header().Del("Content-Type")
+	w.Header().Del("Content-Length")
+	w.Header().Del("X-Content-Type-Options")
+	w.problem = &Problem{Type: "about:blank", Status: code, Title: http.StatusText(code)}
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.problem == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	if n := maxDetail - w.detail.Len(); n > 0 {
+		w.detail.Write(b[:min(n, len(b))])
+	}
+	return len(b), nil
+}
+
+func (w *responseWriter) Flush() {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok && w.problem == nil {
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
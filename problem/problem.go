@@ -0,0 +1,138 @@
+// Package problem renders errors as RFC 7807 problem details, application/problem+json.
+package problem
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. The standard error details of the status errors
+// are rendered as extension members.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Code is the gRPC status code name, e.g. NOT_FOUND.
+	Code     string            `json:"code,omitempty"`
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// RetryAfter is the number of seconds to wait before retrying.
+	RetryAfter             int64                   `json:"retryAfter,omitempty"`
+	FieldViolations        []FieldViolation        `json:"fieldViolations,omitempty"`
+	QuotaViolations        []QuotaViolation        `json:"quotaViolations,omitempty"`
+	PreconditionViolations []PreconditionViolation `json:"preconditionViolations,omitempty"`
+	Resource               *Resource               `json:"resource,omitempty"`
+	Help                   []Link                  `json:"help,omitempty"`
+	LocalizedMessage       *LocalizedMessage       `json:"localizedMessage,omitempty"`
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description,omitempty"`
+}
+
+type QuotaViolation struct {
+	Subject     string `json:"subject"`
+	Description string `json:"description,omitempty"`
+}
+
+type PreconditionViolation struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type Resource struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type Link struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+type LocalizedMessage struct {
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+// FromError returns the problem of the error, with the HTTP status mapped from its gRPC status code.
+func FromError(err error) *Problem {
+	s := status.Convert(err)
+	p := &Problem{
+		Type:   "about:blank",
+		Status: runtime.HTTPStatusFromCode(s.Code()),
+		Detail: s.Message(),
+		Code:   code(s.Code()),
+	}
+	p.Title = http.StatusText(p.Status)
+	if info, ok := errors.ErrorInfo(err); ok {
+		p.Reason, p.Domain, p.Metadata = info.GetReason(), info.GetDomain(), info.GetMetadata()
+	}
+	if d, ok := errors.RetryDelay(err); ok {
+		p.RetryAfter = int64(math.Ceil(d.Seconds()))
+	}
+	for _, v := range errors.FieldViolations(err) {
+		p.FieldViolations = append(p.FieldViolations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+	}
+	for _, v := range errors.QuotaViolations(err) {
+		p.QuotaViolations = append(p.QuotaViolations, QuotaViolation{Subject: v.GetSubject(), Description: v.GetDescription()})
+	}
+	for _, v := range errors.PreconditionViolations(err) {
+		p.PreconditionViolations = append(p.PreconditionViolations, PreconditionViolation{Type: v.GetType(), Subject: v.GetSubject(), Description: v.GetDescription()})
+	}
+	if r, ok := errors.ResourceInfo(err); ok {
+		p.Resource = &Resource{Type: r.GetResourceType(), Name: r.GetResourceName(), Owner: r.GetOwner(), Description: r.GetDescription()}
+	}
+	for _, v := range errors.HelpLinks(err) {
+		p.Help = append(p.Help, Link{Description: v.GetDescription(), URL: v.GetUrl()})
+	}
+	if m, ok := errors.LocalizedMessage(err, ""); ok {
+		p.LocalizedMessage = &LocalizedMessage{Locale: m.GetLocale(), Message: m.GetMessage()}
+	}
+	return p
+}
+
+// Write writes the problem with its Retry-After header if any.
+func (p *Problem) Write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType)
+	if p.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(p.RetryAfter, 10))
+	}
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Error writes the problem of the error, see FromError.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	p := FromError(err)
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+	if p.Code == code(codes.Unauthenticated) {
+		w.Header().Set("WWW-Authenticate", p.Detail)
+	}
+	p.Write(w)
+}
+
+func code(c codes.Code) string {
+	return rpccode.Code(c).String()
+}
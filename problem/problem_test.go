@@ -0,0 +1,98 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"go.linka.cloud/grpc-toolkit/errors"
+)
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) *Problem {
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&p))
+	return &p
+}
+
+func TestErrorHandler(t *testing.T) {
+	err := errors.Build(codes.ResourceExhausted, "too many requests").
+		RetryInfo(1500*time.Millisecond).
+		QuotaViolation("user:bob", "rate limit").
+		ErrorInfo("RATE_LIMITED", "example.com", map[string]string{"limit": "10"}).
+		Err()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{HeaderMD: metadata.Pairs("x-request-id", "42")})
+	rec := httptest.NewRecorder()
+	ErrorHandler(ctx, nil, nil, rec, httptest.NewRequest(http.MethodGet, "/v1/users", nil), err)
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "42", rec.Header().Get(runtime.MetadataHeaderPrefix+"X-Request-Id"))
+	assert.Equal(t, &Problem{
+		Type:            "about:blank",
+		Title:           "Too Many Requests",
+		Status:          http.StatusTooManyRequests,
+		Detail:          "too many requests",
+		Instance:        "/v1/users",
+		Code:            "RESOURCE_EXHAUSTED",
+		Reason:          "RATE_LIMITED",
+		Domain:          "example.com",
+		Metadata:        map[string]string{"limit": "10"},
+		RetryAfter:      2,
+		QuotaViolations: []QuotaViolation{{Subject: "user:bob", Description: "rate limit"}},
+	}, decode(t, rec))
+
+	rec = httptest.NewRecorder()
+	err = errors.Build(codes.InvalidArgument, "invalid").FieldViolation("name", "required").Err()
+	ErrorHandler(context.Background(), nil, nil, rec, httptest.NewRequest(http.MethodPost, "/v1/users", nil), &runtime.HTTPStatusError{HTTPStatus: http.StatusUnprocessableEntity, Err: err})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	p := decode(t, rec)
+	assert.Equal(t, "INVALID_ARGUMENT", p.Code)
+	assert.Equal(t, []FieldViolation{{Field: "name", Description: "required"}}, p.FieldViolations)
+
+	rec = httptest.NewRecorder()
+	Error(rec, nil, errors.Unauthenticatedf("missing token"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "missing token", rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, "Unauthorized", decode(t, rec).Title)
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/error":
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		case "/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{}`)
+		default:
+			fmt.Fprint(w, "ok")
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/error", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, &Problem{Type: "about:blank", Title: "Method Not Allowed", Status: http.StatusMethodNotAllowed, Detail: "method not allowed", Instance: "/error"}, decode(t, rec))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/json", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "{}", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", rec.Body.String())
+}
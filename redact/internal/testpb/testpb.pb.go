@@ -0,0 +1,444 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.2
+// source: redact/internal/testpb/testpb.proto
+
+package testpb
+
+import (
+	_ "go.linka.cloud/grpc-toolkit/redact"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Name          string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password      string                  `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Credentials   *Credentials            `protobuf:"bytes,3,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	History       []*Credentials          `protobuf:"bytes,4,rep,name=history,proto3" json:"history,omitempty"`
+	ByName        map[string]*Credentials `protobuf:"bytes,5,rep,name=by_name,json=byName,proto3" json:"by_name,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Secrets       map[string]string       `protobuf:"bytes,6,rep,name=secrets,proto3" json:"secrets,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Tokens        []string                `protobuf:"bytes,7,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	Pin           int64                   `protobuf:"varint,8,opt,name=pin,proto3" json:"pin,omitempty"`
+	Parent        *User                   `protobuf:"bytes,9,opt,name=parent,proto3" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_redact_internal_testpb_testpb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *User) GetCredentials() *Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *User) GetHistory() []*Credentials {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *User) GetByName() map[string]*Credentials {
+	if x != nil {
+		return x.ByName
+	}
+	return nil
+}
+
+func (x *User) GetSecrets() map[string]string {
+	if x != nil {
+		return x.Secrets
+	}
+	return nil
+}
+
+func (x *User) GetTokens() []string {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *User) GetPin() int64 {
+	if x != nil {
+		return x.Pin
+	}
+	return 0
+}
+
+func (x *User) GetParent() *User {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+type Credentials struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Key           []byte                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_redact_internal_testpb_testpb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Credentials) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *Credentials) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Credentials) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type Plain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Next          *Plain                 `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plain) Reset() {
+	*x = Plain{}
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plain) ProtoMessage() {}
+
+func (x *Plain) ProtoReflect() protoreflect.Message {
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plain.ProtoReflect.Descriptor instead.
+func (*Plain) Descriptor() ([]byte, []int) {
+	return file_redact_internal_testpb_testpb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Plain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Plain) GetNext() *Plain {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tokens        []*Token               `protobuf:"bytes,2,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_redact_internal_testpb_testpb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetTokens() []*Token {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type Token struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_redact_internal_testpb_testpb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_redact_internal_testpb_testpb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Token) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Token) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_redact_internal_testpb_testpb_proto protoreflect.FileDescriptor
+
+const file_redact_internal_testpb_testpb_proto_rawDesc = "" +
+	"\n" +
+	"#redact/internal/testpb/testpb.proto\x12\x11linka.redact.test\x1a\x13redact/redact.proto\"\xba\x04\n" +
+	"\x04User\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\bpassword\x18\x02 \x01(\tB\x04\xe0\xf3\x18\x01R\bpassword\x12@\n" +
+	"\vcredentials\x18\x03 \x01(\v2\x1e.linka.redact.test.CredentialsR\vcredentials\x128\n" +
+	"\ahistory\x18\x04 \x03(\v2\x1e.linka.redact.test.CredentialsR\ahistory\x12<\n" +
+	"\aby_name\x18\x05 \x03(\v2#.linka.redact.test.User.ByNameEntryR\x06byName\x12D\n" +
+	"\asecrets\x18\x06 \x03(\v2$.linka.redact.test.User.SecretsEntryB\x04\xe0\xf3\x18\x01R\asecrets\x12\x1c\n" +
+	"\x06tokens\x18\a \x03(\tB\x04\xe0\xf3\x18\x01R\x06tokens\x12\x16\n" +
+	"\x03pin\x18\b \x01(\x03B\x04\xe0\xf3\x18\x01R\x03pin\x12/\n" +
+	"\x06parent\x18\t \x01(\v2\x17.linka.redact.test.UserR\x06parent\x1aY\n" +
+	"\vByNameEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.linka.redact.test.CredentialsR\x05value:\x028\x01\x1a:\n" +
+	"\fSecretsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"M\n" +
+	"\vCredentials\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\fR\x03key\"I\n" +
+	"\x05Plain\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12,\n" +
+	"\x04next\x18\x02 \x01(\v2\x18.linka.redact.test.PlainR\x04next\"K\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\x06tokens\x18\x02 \x03(\v2\x18.linka.redact.test.TokenR\x06tokens\"-\n" +
+	"\x05Token\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05valueB4Z2go.linka.cloud/grpc-toolkit/redact/internal/testpbb\x06proto3"
+
+var (
+	file_redact_internal_testpb_testpb_proto_rawDescOnce sync.Once
+	file_redact_internal_testpb_testpb_proto_rawDescData []byte
+)
+
+func file_redact_internal_testpb_testpb_proto_rawDescGZIP() []byte {
+	file_redact_internal_testpb_testpb_proto_rawDescOnce.Do(func() {
+		file_redact_internal_testpb_testpb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_redact_internal_testpb_testpb_proto_rawDesc), len(file_redact_internal_testpb_testpb_proto_rawDesc)))
+	})
+	return file_redact_internal_testpb_testpb_proto_rawDescData
+}
+
+var file_redact_internal_testpb_testpb_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_redact_internal_testpb_testpb_proto_goTypes = []any{
+	(*User)(nil),        // 0: linka.redact.test.User
+	(*Credentials)(nil), // 1: linka.redact.test.Credentials
+	(*Plain)(nil),       // 2: linka.redact.test.Plain
+	(*Session)(nil),     // 3: linka.redact.test.Session
+	(*Token)(nil),       // 4: linka.redact.test.Token
+	nil,                 // 5: linka.redact.test.User.ByNameEntry
+	nil,                 // 6: linka.redact.test.User.SecretsEntry
+}
+var file_redact_internal_testpb_testpb_proto_depIdxs = []int32{
+	1, // 0: linka.redact.test.User.credentials:type_name -> linka.redact.test.Credentials
+	1, // 1: linka.redact.test.User.history:type_name -> linka.redact.test.Credentials
+	5, // 2: linka.redact.test.User.by_name:type_name -> linka.redact.test.User.ByNameEntry
+	6, // 3: linka.redact.test.User.secrets:type_name -> linka.redact.test.User.SecretsEntry
+	0, // 4: linka.redact.test.User.parent:type_name -> linka.redact.test.User
+	2, // 5: linka.redact.test.Plain.next:type_name -> linka.redact.test.Plain
+	4, // 6: linka.redact.test.Session.tokens:type_name -> linka.redact.test.Token
+	1, // 7: linka.redact.test.User.ByNameEntry.value:type_name -> linka.redact.test.Credentials
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_redact_internal_testpb_testpb_proto_init() }
+func file_redact_internal_testpb_testpb_proto_init() {
+	if File_redact_internal_testpb_testpb_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redact_internal_testpb_testpb_proto_rawDesc), len(file_redact_internal_testpb_testpb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_redact_internal_testpb_testpb_proto_goTypes,
+		DependencyIndexes: file_redact_internal_testpb_testpb_proto_depIdxs,
+		MessageInfos:      file_redact_internal_testpb_testpb_proto_msgTypes,
+	}.Build()
+	File_redact_internal_testpb_testpb_proto = out.File
+	file_redact_internal_testpb_testpb_proto_goTypes = nil
+	file_redact_internal_testpb_testpb_proto_depIdxs = nil
+}
@@ -0,0 +1,226 @@
+package redact
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Placeholder replaces the redacted string values.
+const Placeholder = "[REDACTED]"
+
+// Redact returns a redacted copy of the message using the (linka.sensitive) option, see Redactor.Redact.
+func Redact(m proto.Message, paths ...string) proto.Message {
+	return defaultRedactor.Redact(m, paths...)
+}
+
+// Redact returns a redacted copy of the message: the fields marked with the (linka.sensitive) option,
+// the fields registered for the messages types and the fields matching the given field mask paths,
+// e.g. credentials.password, are cleared, or replaced with Placeholder for strings.
+// The paths go through the repeated and map message fields, applying to all their elements.
+// The messages packed in google.protobuf.Any fields are redacted as well, the paths going through the Any
+// apply to the packed message. The value of the Any whose type is not in the global registry is cleared.
+// The message itself is returned if there is nothing to redact.
+func (r *Redactor) Redact(m proto.Message, paths ...string) proto.Message {
+	if r == nil {
+		r = defaultRedactor
+	}
+	if m == nil {
+		return nil
+	}
+	pr := m.ProtoReflect()
+	if !pr.IsValid() || (len(paths) == 0 && !r.hasSensitive(pr.Descriptor())) {
+		return m
+	}
+	c := proto.Clone(m)
+	r.redact(c.ProtoReflect(), newTree(paths))
+	return c
+}
+
+// tree is a tree of field names, the leaves are the fields to redact.
+type tree map[protoreflect.Name]tree
+
+func newTree(paths []string) tree {
+	t := tree{}
+	for _, p := range paths {
+		n := t
+		parts := strings.Split(p, ".")
+		for i, v := range parts {
+			name := protoreflect.Name(v)
+			if i == len(parts)-1 {
+				n[name] = nil
+				break
+			}
+			c, ok := n[name]
+			if ok && c == nil {
+				// the parent is already redacted
+				break
+			}
+			if !ok {
+				c = tree{}
+				n[name] = c
+			}
+			n = c
+		}
+	}
+	return t
+}
+
+// merge returns the merged children of the two trees for the name,
+// ok is false if none of them contains the name.
+func (t tree) merge(o tree, name protoreflect.Name) (c tree, ok bool) {
+	c1, ok1 := t[name]
+	c2, ok2 := o[name]
+	switch {
+	case ok1 && c1 == nil, ok2 && c2 == nil:
+		return nil, true
+	case !ok2:
+		return c1, ok1
+	case !ok1:
+		return c2, ok2
+	}
+	c = tree{}
+	for k := range c1 {
+		c[k], _ = c1.merge(c2, k)
+	}
+	for k := range c2 {
+		c[k], _ = c1.merge(c2, k)
+	}
+	return c, true
+}
+
+func (r *Redactor) redact(m protoreflect.Message, t tree) {
+	if m.Descriptor().FullName() == anyName {
+		r.redactAny(m, t)
+		return
+	}
+	reg := r.registered(m.Descriptor().FullName())
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		c, ok := t.merge(reg, fd.Name())
+		if IsSensitive(fd) || (ok && c == nil) {
+			redactField(m, fd, v)
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				return true
+			}
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				r.redact(v.Message(), c)
+				return true
+			})
+		case fd.IsList():
+			if fd.Message() == nil {
+				return true
+			}
+			for i := 0; i < v.List().Len(); i++ {
+				r.redact(v.List().Get(i).Message(), c)
+			}
+		case fd.Message() != nil:
+			r.redact(v.Message(), c)
+		}
+		return true
+	})
+}
+
+const anyName protoreflect.FullName = "google.protobuf.Any"
+
+// redactAny redacts the message packed in the Any.
+func (r *Redactor) redactAny(m protoreflect.Message, t tree) {
+	fields := m.Descriptor().Fields()
+	url, value := fields.ByName("type_url"), fields.ByName("value")
+	if url == nil || value == nil || !m.Has(value) {
+		return
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(m.Get(url).String())
+	if err != nil {
+		m.Clear(value)
+		return
+	}
+	v := mt.New()
+	if err := proto.Unmarshal(m.Get(value).Bytes(), v.Interface()); err != nil {
+		m.Clear(value)
+		return
+	}
+	if len(t) == 0 && !r.hasSensitive(v.Descriptor()) {
+		return
+	}
+	r.redact(v, t)
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(v.Interface())
+	if err != nil {
+		m.Clear(value)
+		return
+	}
+	m.Set(value, protoreflect.ValueOfBytes(b))
+}
+
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Kind() != protoreflect.StringKind {
+			m.Clear(fd)
+			return
+		}
+		v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			v.Map().Set(k, protoreflect.ValueOfString(Placeholder))
+			return true
+		})
+	case fd.IsList():
+		if fd.Kind() != protoreflect.StringKind {
+			m.Clear(fd)
+			return
+		}
+		for i := 0; i < v.List().Len(); i++ {
+			v.List().Set(i, protoreflect.ValueOfString(Placeholder))
+		}
+	case fd.Kind() == protoreflect.StringKind:
+		m.Set(fd, protoreflect.ValueOfString(Placeholder))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// IsSensitive reports whether the field is marked with the (linka.sensitive) option.
+func IsSensitive(fd protoreflect.FieldDescriptor) bool {
+	o := fd.Options()
+	return o != nil && proto.HasExtension(o, E_Sensitive) && proto.GetExtension(o, E_Sensitive).(bool)
+}
+
+// hasSensitive reports whether the message or one of its nested messages has a sensitive or registered field.
+func (r *Redactor) hasSensitive(md protoreflect.MessageDescriptor) bool {
+	if v, ok := r.sensitive.Load(md.FullName()); ok {
+		return v.(bool)
+	}
+	// only the root result is cached as the nested ones may be partial because of the cycles
+	v := r.walkSensitive(md, map[protoreflect.FullName]bool{})
+	r.sensitive.Store(md.FullName(), v)
+	return v
+}
+
+func (r *Redactor) walkSensitive(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) bool {
+	if seen[md.FullName()] {
+		return false
+	}
+	seen[md.FullName()] = true
+	// the content of the Any fields is only known at runtime
+	if md.FullName() == anyName || r.registered(md.FullName()) != nil {
+		return true
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if IsSensitive(fd) {
+			return true
+		}
+		if fd.IsMap() {
+			fd = fd.MapValue()
+		}
+		if fd.Message() != nil && r.walkSensitive(fd.Message(), seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package redact_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"go.linka.cloud/grpc-toolkit/redact"
+	"go.linka.cloud/grpc-toolkit/redact/internal/testpb"
+)
+
+func user() *testpb.User {
+	return &testpb.User{
+		Name:        "user",
+		Password:    "password",
+		Credentials: &testpb.Credentials{Login: "login", Secret: "secret", Key: []byte("key")},
+		History:     []*testpb.Credentials{{Login: "old", Secret: "old"}},
+		ByName:      map[string]*testpb.Credentials{"other": {Login: "other", Secret: "other"}},
+		Secrets:     map[string]string{"api": "key"},
+		Tokens:      []string{"a", "b"},
+		Pin:         1234,
+		Parent:      &testpb.User{Name: "parent", Password: "password"},
+	}
+}
+
+func TestRedactOption(t *testing.T) {
+	u := user()
+	r := redact.Redact(u).(*testpb.User)
+	assert.True(t, proto.Equal(user(), u), "the message should not be modified")
+
+	assert.Equal(t, "user", r.Name)
+	assert.Equal(t, redact.Placeholder, r.Password)
+	assert.Equal(t, map[string]string{"api": redact.Placeholder}, r.Secrets)
+	assert.Equal(t, []string{redact.Placeholder, redact.Placeholder}, r.Tokens)
+	assert.Zero(t, r.Pin)
+	assert.Equal(t, redact.Placeholder, r.Parent.Password)
+	assert.Equal(t, "parent", r.Parent.Name)
+	assert.Equal(t, "secret", r.Credentials.Secret)
+}
+
+func TestRedactPaths(t *testing.T) {
+	r := redact.Redact(user(), "credentials.secret", "credentials.key", "history.secret", "by_name.secret", "parent").(*testpb.User)
+	assert.Equal(t, "login", r.Credentials.Login)
+	assert.Equal(t, redact.Placeholder, r.Credentials.Secret)
+	assert.Nil(t, r.Credentials.Key)
+	assert.Equal(t, redact.Placeholder, r.History[0].Secret)
+	assert.Equal(t, "old", r.History[0].Login)
+	assert.Equal(t, redact.Placeholder, r.ByName["other"].Secret)
+	assert.Nil(t, r.Parent)
+}
+
+func TestRedactNothing(t *testing.T) {
+	p := &testpb.Plain{Name: "plain", Next: &testpb.Plain{Name: "next"}}
+	assert.Same(t, p, redact.Redact(p))
+	assert.Nil(t, redact.Redact(nil))
+	r := redact.Redact(p, "next.name").(*testpb.Plain)
+	assert.NotSame(t, p, r)
+	assert.Equal(t, redact.Placeholder, r.Next.Name)
+	assert.Equal(t, "next", p.Next.Name)
+}
+
+func TestRedactor(t *testing.T) {
+	s := &testpb.Session{Id: "session", Tokens: []*testpb.Token{{Id: "token", Value: "value"}}}
+	red := redact.New(redact.WithFieldMask(&testpb.Token{}, &fieldmaskpb.FieldMask{Paths: []string{"value"}}))
+	assert.Same(t, s, redact.Redact(s))
+	assert.Same(t, s, (*redact.Redactor)(nil).Redact(s))
+
+	r := red.Redact(s).(*testpb.Session)
+	assert.NotSame(t, s, r)
+	assert.Equal(t, "session", r.Id)
+	assert.Equal(t, "token", r.Tokens[0].Id)
+	assert.Equal(t, redact.Placeholder, r.Tokens[0].Value)
+	assert.Equal(t, "value", s.Tokens[0].Value)
+
+	r = red.Redact(s, "tokens.id").(*testpb.Session)
+	assert.Equal(t, redact.Placeholder, r.Tokens[0].Id)
+	assert.Equal(t, redact.Placeholder, r.Tokens[0].Value)
+}
+
+func TestRedactAny(t *testing.T) {
+	a, err := anypb.New(user())
+	require.NoError(t, err)
+	r := redact.Redact(a, "credentials.secret").(*anypb.Any)
+	u := &testpb.User{}
+	require.NoError(t, r.UnmarshalTo(u))
+	assert.Equal(t, redact.Placeholder, u.Password)
+	assert.Equal(t, redact.Placeholder, u.Credentials.Secret)
+	assert.Equal(t, "user", u.Name)
+	require.NoError(t, a.UnmarshalTo(u))
+	assert.Equal(t, "password", u.Password, "the message should not be modified")
+
+	unknown := &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Type", Value: []byte("secret")}
+	r = redact.Redact(unknown).(*anypb.Any)
+	assert.Equal(t, unknown.TypeUrl, r.TypeUrl)
+	assert.Empty(t, r.Value)
+}
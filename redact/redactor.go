@@ -0,0 +1,62 @@
+package redact
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Redactor redacts the fields marked with the (linka.sensitive) option and the fields registered
+// for the messages types with WithPaths or WithFieldMask, wherever these messages are nested.
+// A nil Redactor only redacts the fields marked with the (linka.sensitive) option.
+type Redactor struct {
+	trees map[protoreflect.FullName]tree
+	// sensitive caches whether the messages have fields to redact
+	sensitive sync.Map
+}
+
+type Option func(*options)
+
+// WithPaths marks the fields at the given paths, e.g. credentials.password, as sensitive in the messages
+// of the same type as m, including when they are nested in another message.
+// It is meant for the messages which definition cannot be annotated with the (linka.sensitive) option.
+func WithPaths(m proto.Message, paths ...string) Option {
+	return func(o *options) {
+		n := m.ProtoReflect().Descriptor().FullName()
+		o.paths[n] = append(o.paths[n], paths...)
+	}
+}
+
+// WithFieldMask marks the field mask paths, e.g. built with the protoc-gen-go-fields generated
+// field names, as sensitive in the messages of the same type as m. See WithPaths.
+func WithFieldMask(m proto.Message, mask *fieldmaskpb.FieldMask) Option {
+	return WithPaths(m, mask.GetPaths()...)
+}
+
+type options struct {
+	paths map[protoreflect.FullName][]string
+}
+
+// New returns a Redactor using the given paths in addition to the (linka.sensitive) option.
+func New(opts ...Option) *Redactor {
+	o := options{paths: make(map[protoreflect.FullName][]string)}
+	for _, v := range opts {
+		v(&o)
+	}
+	r := &Redactor{trees: make(map[protoreflect.FullName]tree, len(o.paths))}
+	for k, v := range o.paths {
+		if len(v) != 0 {
+			r.trees[k] = newTree(v)
+		}
+	}
+	return r
+}
+
+// defaultRedactor only uses the (linka.sensitive) option.
+var defaultRedactor = New()
+
+func (r *Redactor) registered(n protoreflect.FullName) tree {
+	return r.trees[n]
+}
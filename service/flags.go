@@ -3,9 +3,13 @@ package service
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/caitlinelfring/go-env-default"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc/keepalive"
+
+	"go.linka.cloud/grpc-toolkit/transport"
 )
 
 const (
@@ -21,6 +25,14 @@ const (
 	clientCACert = "client-ca-cert"
 	clientCert   = "client-cert"
 	clientKey    = "client-key"
+
+	keepaliveTime         = "keepalive-time"
+	keepaliveTimeout      = "keepalive-timeout"
+	keepaliveMinTime      = "keepalive-min-time"
+	maxRecvMsgSize        = "max-recv-msg-size"
+	maxSendMsgSize        = "max-send-msg-size"
+	initialWindowSize     = "initial-window-size"
+	initialConnWindowSize = "initial-conn-window-size"
 )
 
 var u = strings.ToUpper
@@ -33,6 +45,14 @@ func NewFlagSet() (*pflag.FlagSet, Option) {
 		optCACert     string
 		optCert       string
 		optKey        string
+
+		optKeepaliveTime         time.Duration
+		optKeepaliveTimeout      time.Duration
+		optKeepaliveMinTime      time.Duration
+		optMaxRecvMsgSize        int
+		optMaxSendMsgSize        int
+		optInitialWindowSize     int32
+		optInitialConnWindowSize int32
 	)
 	flags := pflag.NewFlagSet("gRPC", pflag.ContinueOnError)
 	flags.StringVarP(&optAddress, serverAddress, "a", env.GetDefault(u(serverAddress), "0.0.0.0:0"), "Bind address for the server, e.g. 127.0.0.1:9090"+flagEnv(serverAddress))
@@ -44,6 +64,13 @@ func NewFlagSet() (*pflag.FlagSet, Option) {
 	flags.StringVar(&optCACert, clientCACert, "", "Path to Root CA certificate"+flagEnv(clientCACert))
 	flags.StringVar(&optCert, clientCert, "", "Path to Client certificate"+flagEnv(clientCert))
 	flags.StringVar(&optKey, clientKey, "", "Path to Client key"+flagEnv(clientKey))
+	flags.DurationVar(&optKeepaliveTime, keepaliveTime, env.GetDurationDefault(u(keepaliveTime), 0), "Interval of the server keepalive pings, 0 keeps the gRPC default"+flagEnv(keepaliveTime))
+	flags.DurationVar(&optKeepaliveTimeout, keepaliveTimeout, env.GetDurationDefault(u(keepaliveTimeout), 0), "Time to wait for a keepalive ping ack, 0 keeps the gRPC default"+flagEnv(keepaliveTimeout))
+	flags.DurationVar(&optKeepaliveMinTime, keepaliveMinTime, env.GetDurationDefault(u(keepaliveMinTime), transport.DefaultKeepaliveMinTime), "Minimum interval allowed between client keepalive pings"+flagEnv(keepaliveMinTime))
+	flags.IntVar(&optMaxRecvMsgSize, maxRecvMsgSize, env.GetIntDefault(u(maxRecvMsgSize), transport.DefaultMaxRecvMsgSize), "Maximum message size in bytes the server can receive"+flagEnv(maxRecvMsgSize))
+	flags.IntVar(&optMaxSendMsgSize, maxSendMsgSize, env.GetIntDefault(u(maxSendMsgSize), transport.DefaultMaxSendMsgSize), "Maximum message size in bytes the server can send, 0 does not limit it"+flagEnv(maxSendMsgSize))
+	flags.Int32Var(&optInitialWindowSize, initialWindowSize, int32(env.GetIntDefault(u(initialWindowSize), int(transport.DefaultInitialWindowSize))), "Stream flow control window size in bytes, 0 enables dynamic window sizing"+flagEnv(initialWindowSize))
+	flags.Int32Var(&optInitialConnWindowSize, initialConnWindowSize, int32(env.GetIntDefault(u(initialConnWindowSize), int(transport.DefaultInitialConnWindowSize))), "Connection flow control window size in bytes, 0 enables dynamic window sizing"+flagEnv(initialConnWindowSize))
 	return flags, func(o *options) {
 		o.address = optAddress
 		o.secure = !optInsecure
@@ -54,6 +81,14 @@ func NewFlagSet() (*pflag.FlagSet, Option) {
 		o.clientCACert = optCACert
 		o.clientCert = optCert
 		o.clientKey = optKey
+		if optKeepaliveTime != 0 || optKeepaliveTimeout != 0 {
+			o.keepalive = &keepalive.ServerParameters{Time: optKeepaliveTime, Timeout: optKeepaliveTimeout}
+		}
+		o.keepalivePolicy.MinTime = optKeepaliveMinTime
+		o.maxRecvMsgSize = optMaxRecvMsgSize
+		o.maxSendMsgSize = optMaxSendMsgSize
+		o.initialWindowSize = optInitialWindowSize
+		o.initialConnWindowSize = optInitialConnWindowSize
 	}
 }
 
@@ -17,7 +17,9 @@ func (s *service) gateway(opts ...runtime.ServeMuxOption) error {
 	if !s.opts.Gateway() {
 		return nil
 	}
-	mux := runtime.NewServeMux(append(defaultGatewayOptions, opts...)...)
+	mopts := append([]runtime.ServeMuxOption{}, defaultGatewayOptions...)
+	mopts = append(mopts, runtime.WithErrorHandler(s.opts.gatewayErrorHandler))
+	mux := runtime.NewServeMux(append(mopts, opts...)...)
 	if err := s.opts.gateway(s.opts.ctx, mux, s.wrapCC()); err != nil {
 		return err
 	}
@@ -14,9 +14,11 @@ import (
 	"github.com/rs/cors"
 	"github.com/traefik/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 
 	"go.linka.cloud/grpc-toolkit/certs"
 	"go.linka.cloud/grpc-toolkit/interceptors"
+	"go.linka.cloud/grpc-toolkit/problem"
 	"go.linka.cloud/grpc-toolkit/registry"
 	"go.linka.cloud/grpc-toolkit/transport"
 	"go.linka.cloud/grpc-toolkit/utils/addr"
@@ -52,6 +54,12 @@ type Options interface {
 	AfterStop() []func() error
 
 	ServerOpts() []grpc.ServerOption
+	Keepalive() *keepalive.ServerParameters
+	KeepaliveEnforcementPolicy() keepalive.EnforcementPolicy
+	MaxRecvMsgSize() int
+	MaxSendMsgSize() int
+	InitialWindowSize() int32
+	InitialConnWindowSize() int32
 	ServerInterceptors() []grpc.UnaryServerInterceptor
 	StreamServerInterceptors() []grpc.StreamServerInterceptor
 
@@ -67,6 +75,7 @@ type Options interface {
 	Gateway() bool
 	GatewayPrefix() string
 	GatewayOpts() []runtime.ServeMuxOption
+	GatewayErrorHandler() runtime.ErrorHandlerFunc
 
 	// TODO(adphi): metrics + tracing
 
@@ -81,6 +90,15 @@ func NewOptions() *options {
 		ctx:     context.Background(),
 		address: ":0",
 		health:  true,
+		keepalivePolicy: keepalive.EnforcementPolicy{
+			MinTime:             transport.DefaultKeepaliveMinTime,
+			PermitWithoutStream: true,
+		},
+		maxRecvMsgSize:        transport.DefaultMaxRecvMsgSize,
+		maxSendMsgSize:        transport.DefaultMaxSendMsgSize,
+		initialWindowSize:     transport.DefaultInitialWindowSize,
+		initialConnWindowSize: transport.DefaultInitialConnWindowSize,
+		gatewayErrorHandler:   problem.ErrorHandler,
 	}
 }
 
@@ -91,6 +109,9 @@ func (o *options) Default() {
 	if o.address == "" {
 		o.address = "0.0.0.0:0"
 	}
+	if o.gatewayErrorHandler == nil {
+		o.gatewayErrorHandler = problem.ErrorHandler
+	}
 	if o.transport == nil {
 		o.transport = &grpc.Server{}
 	}
@@ -165,6 +186,57 @@ func WithGRPCServerOpts(opts ...grpc.ServerOption) Option {
 	}
 }
 
+// WithKeepalive sets the server keepalive parameters, e.g. the connection max age
+// or the interval of the server pings. Zero fields keep the gRPC defaults.
+func WithKeepalive(params keepalive.ServerParameters) Option {
+	return func(o *options) {
+		o.keepalive = &params
+	}
+}
+
+// WithKeepaliveEnforcementPolicy sets the policy the server applies to client pings.
+// It defaults to allowing pings every transport.DefaultKeepaliveMinTime, even without active streams,
+// which matches the client.WithKeepalive defaults.
+func WithKeepaliveEnforcementPolicy(policy keepalive.EnforcementPolicy) Option {
+	return func(o *options) {
+		o.keepalivePolicy = policy
+	}
+}
+
+// WithMaxRecvMsgSize sets the maximum message size in bytes the server can receive.
+// It defaults to transport.DefaultMaxRecvMsgSize.
+func WithMaxRecvMsgSize(size int) Option {
+	return func(o *options) {
+		o.maxRecvMsgSize = size
+	}
+}
+
+// WithMaxSendMsgSize sets the maximum message size in bytes the server can send.
+// The sent messages size is not limited by default.
+func WithMaxSendMsgSize(size int) Option {
+	return func(o *options) {
+		o.maxSendMsgSize = size
+	}
+}
+
+// WithInitialWindowSize sets the stream flow control window size.
+// Values lower than 64KB are ignored. The default, zero, keeps gRPC dynamic window sizing,
+// which any other value disables.
+func WithInitialWindowSize(size int32) Option {
+	return func(o *options) {
+		o.initialWindowSize = size
+	}
+}
+
+// WithInitialConnWindowSize sets the connection flow control window size.
+// Values lower than 64KB are ignored. The default, zero, keeps gRPC dynamic window sizing,
+// which any other value disables.
+func WithInitialConnWindowSize(size int32) Option {
+	return func(o *options) {
+		o.initialConnWindowSize = size
+	}
+}
+
 func WithCACert(path string) Option {
 	return func(o *options) {
 		o.caCert = path
@@ -231,6 +303,7 @@ func WithAfterStop(fn ...func() error) Option {
 	}
 }
 
+// WithInterceptors adds the client and server interceptors, see WithServerInterceptors.
 func WithInterceptors(i ...interceptors.Interceptors) Option {
 	return func(o *options) {
 		for _, v := range i {
@@ -238,19 +311,34 @@ func WithInterceptors(i ...interceptors.Interceptors) Option {
 			o.streamServerInterceptors = append(o.streamServerInterceptors, v.StreamServerInterceptor())
 			o.unaryClientInterceptors = append(o.unaryClientInterceptors, v.UnaryClientInterceptor())
 			o.streamClientInterceptors = append(o.streamClientInterceptors, v.StreamClientInterceptor())
+			o.closeAfterStop(v)
 		}
 	}
 }
 
+// WithServerInterceptors adds the server interceptors. The interceptors with a Close(context.Context) error method,
+// e.g. the audit ones, are closed after the server stopped.
 func WithServerInterceptors(i ...interceptors.ServerInterceptors) Option {
 	return func(o *options) {
 		for _, v := range i {
 			o.unaryServerInterceptors = append(o.unaryServerInterceptors, v.UnaryServerInterceptor())
 			o.streamServerInterceptors = append(o.streamServerInterceptors, v.StreamServerInterceptor())
+			o.closeAfterStop(v)
 		}
 	}
 }
 
+// closeAfterStop closes the interceptors with a Close method once the server stopped, so that they can flush their state.
+func (o *options) closeAfterStop(i any) {
+	c, ok := i.(interface{ Close(context.Context) error })
+	if !ok {
+		return
+	}
+	o.afterStop = append(o.afterStop, func() error {
+		return c.Close(context.Background())
+	})
+}
+
 func WithClientInterceptors(i ...interceptors.ClientInterceptors) Option {
 	return func(o *options) {
 		for _, v := range i {
@@ -346,6 +434,19 @@ func WithGatewayOpts(opts ...runtime.ServeMuxOption) Option {
 	}
 }
 
+// WithGatewayErrorHandler sets the gateway error handler, problem.ErrorHandler by default,
+// rendering the errors as RFC 7807 application/problem+json responses.
+// A nil handler restores the grpc-gateway default one.
+// The plain HTTP errors of the gRPC-Web and react UI routes are rendered as problems regardless.
+func WithGatewayErrorHandler(h runtime.ErrorHandlerFunc) Option {
+	return func(o *options) {
+		if h == nil {
+			h = runtime.DefaultHTTPErrorHandler
+		}
+		o.gatewayErrorHandler = h
+	}
+}
+
 // WithReactUI add static single page app serving to the http server
 // subpath is the path in the read-only file embed.FS to use as root to serve
 // static content
@@ -400,6 +501,13 @@ type options struct {
 
 	serverOpts []grpc.ServerOption
 
+	keepalive             *keepalive.ServerParameters
+	keepalivePolicy       keepalive.EnforcementPolicy
+	maxRecvMsgSize        int
+	maxSendMsgSize        int
+	initialWindowSize     int32
+	initialConnWindowSize int32
+
 	unaryServerInterceptors  []grpc.UnaryServerInterceptor
 	streamServerInterceptors []grpc.StreamServerInterceptor
 
@@ -415,6 +523,8 @@ type options struct {
 	gatewayOpts   []runtime.ServeMuxOption
 	cors          cors.Options
 
+	gatewayErrorHandler runtime.ErrorHandlerFunc
+
 	reactUI        fs.FS
 	reactUISubPath string
 	hasReactUI     bool
@@ -506,6 +616,52 @@ func (o *options) ServerOpts() []grpc.ServerOption {
 	return o.serverOpts
 }
 
+func (o *options) Keepalive() *keepalive.ServerParameters {
+	return o.keepalive
+}
+
+func (o *options) KeepaliveEnforcementPolicy() keepalive.EnforcementPolicy {
+	return o.keepalivePolicy
+}
+
+func (o *options) MaxRecvMsgSize() int {
+	return o.maxRecvMsgSize
+}
+
+func (o *options) MaxSendMsgSize() int {
+	return o.maxSendMsgSize
+}
+
+func (o *options) InitialWindowSize() int32 {
+	return o.initialWindowSize
+}
+
+func (o *options) InitialConnWindowSize() int32 {
+	return o.initialConnWindowSize
+}
+
+// transportServerOptions returns the server options built from the typed transport options.
+// They come before the ones given with WithGRPCServerOpts so that the latter take precedence.
+func (o *options) transportServerOptions() []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(o.keepalivePolicy),
+		grpc.MaxRecvMsgSize(o.maxRecvMsgSize),
+	}
+	if o.maxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(o.maxSendMsgSize))
+	}
+	if o.keepalive != nil {
+		opts = append(opts, grpc.KeepaliveParams(*o.keepalive))
+	}
+	if o.initialWindowSize != 0 {
+		opts = append(opts, grpc.InitialWindowSize(o.initialWindowSize))
+	}
+	if o.initialConnWindowSize != 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(o.initialConnWindowSize))
+	}
+	return opts
+}
+
 func (o *options) ServerInterceptors() []grpc.UnaryServerInterceptor {
 	return o.unaryServerInterceptors
 }
@@ -554,6 +710,10 @@ func (o *options) GatewayOpts() []runtime.ServeMuxOption {
 	return o.gatewayOpts
 }
 
+func (o *options) GatewayErrorHandler() runtime.ErrorHandlerFunc {
+	return o.gatewayErrorHandler
+}
+
 func (o *options) WithoutCmux() bool {
 	return o.withoutCmux
 }
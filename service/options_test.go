@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	insecure2 "google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// call sends a small request to a server built with the transport options, which replies with a 5MiB message.
+func call(t *testing.T, opts ...Option) error {
+	o := NewOptions()
+	for _, v := range opts {
+		v(o)
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(append(o.transportServerOptions(), grpc.UnknownServiceHandler(func(srv any, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(&wrapperspb.BytesValue{}); err != nil {
+			return err
+		}
+		return ss.SendMsg(wrapperspb.Bytes(make([]byte, 5<<20)))
+	}))...)
+	go s.Serve(lis)
+	defer s.Stop()
+	cc, err := grpc.NewClient("passthrough:///bufnet", grpc.WithTransportCredentials(insecure2.NewCredentials()), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	defer cc.Close()
+	return cc.Invoke(context.Background(), "/test.Echo/Echo", wrapperspb.Bytes(nil), &wrapperspb.BytesValue{}, grpc.MaxCallRecvMsgSize(16<<20))
+}
+
+func TestTransportOptions(t *testing.T) {
+	// the sent messages are not limited by default
+	require.NoError(t, call(t))
+	err := call(t, WithMaxSendMsgSize(1<<20))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+type closingInterceptors struct {
+	closed int
+}
+
+func (c *closingInterceptors) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ctx, req)
+	}
+}
+
+func (c *closingInterceptors) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	}
+}
+
+func (c *closingInterceptors) Close(ctx context.Context) error {
+	c.closed++
+	return nil
+}
+
+func TestInterceptorsClose(t *testing.T) {
+	c := &closingInterceptors{}
+	o := NewOptions()
+	WithServerInterceptors(c)(o)
+	for _, fn := range o.AfterStop() {
+		require.NoError(t, fn())
+	}
+	assert.Equal(t, 1, c.closed)
+}
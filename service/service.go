@@ -25,10 +25,12 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	greflect "google.golang.org/grpc/reflection"
 
+	_ "go.linka.cloud/grpc-toolkit/codec/zstd"
 	"go.linka.cloud/grpc-toolkit/creds/peercreds"
 	"go.linka.cloud/grpc-toolkit/interceptors/chain"
 	"go.linka.cloud/grpc-toolkit/internal/injectlogger"
@@ -143,6 +145,7 @@ func newService(opts ...Option) (*service, error) {
 		strings.HasPrefix(s.opts.address, "unix://") || strings.HasPrefix(s.opts.address, `\\.\pipe\`) {
 		gopts = append(gopts, grpc.Creds(peercreds.New()))
 	}
+	gopts = append(gopts, s.opts.transportServerOptions()...)
 	s.server = grpc.NewServer(append(gopts, s.opts.serverOpts...)...)
 	if s.opts.reflection {
 		greflect.Register(s.server)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/traefik/grpc-web/go/grpcweb"
 
+	"go.linka.cloud/grpc-toolkit/problem"
 	"go.linka.cloud/grpc-toolkit/react"
 )
 
@@ -25,7 +26,7 @@ func (s *service) grpcWeb(opts ...grpcweb.Option) error {
 	if !s.opts.grpcWeb {
 		return nil
 	}
-	h := grpcweb.WrapServer(s.server, append(defaultWebOptions, opts...)...)
+	h := problem.Handler(grpcweb.WrapServer(s.server, append(defaultWebOptions, opts...)...))
 	for _, v := range grpcweb.ListGRPCResources(s.server) {
 		if s.opts.grpcWebPrefix != "" {
 			s.lazyMux().Handle(s.opts.grpcWebPrefix+v, http.StripPrefix(s.opts.grpcWebPrefix, h))
@@ -44,6 +45,6 @@ func (s *service) reactApp() error {
 	if err != nil {
 		return err
 	}
-	s.lazyMux().Handle("/", h)
+	s.lazyMux().Handle("/", problem.Handler(h))
 	return nil
 }
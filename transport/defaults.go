@@ -0,0 +1,33 @@
+package transport
+
+import (
+	"time"
+)
+
+// Defaults shared by the client and the service packages so that both ends
+// of a connection agree with each other.
+const (
+	// DefaultKeepaliveTime is the interval after which a client with keepalive
+	// enabled pings the server if it did not see any activity.
+	DefaultKeepaliveTime = 30 * time.Second
+	// DefaultKeepaliveTimeout is the time to wait for a keepalive ping ack
+	// before closing the connection.
+	DefaultKeepaliveTimeout = 10 * time.Second
+	// DefaultKeepaliveMinTime is the minimum interval the server allows between
+	// client pings. It must stay below DefaultKeepaliveTime, otherwise the server
+	// closes the connection with a GOAWAY "too_many_pings".
+	DefaultKeepaliveMinTime = 15 * time.Second
+
+	// DefaultMaxRecvMsgSize is the maximum message size in bytes an endpoint accepts.
+	DefaultMaxRecvMsgSize = 4 << 20
+	// DefaultMaxSendMsgSize is the maximum message size in bytes an endpoint sends.
+	// Zero keeps the gRPC default, which does not limit the sent messages size.
+	DefaultMaxSendMsgSize = 0
+
+	// DefaultInitialWindowSize is the stream flow control window size.
+	// Zero keeps gRPC dynamic window sizing based on the bandwidth delay product.
+	DefaultInitialWindowSize int32 = 0
+	// DefaultInitialConnWindowSize is the connection flow control window size.
+	// Zero keeps gRPC dynamic window sizing based on the bandwidth delay product.
+	DefaultInitialConnWindowSize int32 = 0
+)